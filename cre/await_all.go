@@ -0,0 +1,171 @@
+package cre
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+)
+
+// BatchAwaiter is meant to be implemented by Runtime implementations.
+// It settles several outstanding capability calls, identified by their callback IDs, in a single host round-trip.
+type BatchAwaiter interface {
+	AwaitCapabilities(ids []int32) (map[int32]*sdk.CapabilityResponse, error)
+}
+
+// NewCapabilityPromise is meant to be used by Runtime implementations.
+// It creates a Promise for an outstanding capability call that is settled by the awaiter once awaited.
+// Unlike NewBasicPromise, the callback ID is recorded so that AwaitAll can settle many such promises together.
+func NewCapabilityPromise(callbackID int32, awaiter BatchAwaiter) Promise[*sdk.CapabilityResponse] {
+	return &capabilityPromise{callbackID: callbackID, awaiter: awaiter}
+}
+
+type capabilityPromise struct {
+	callbackID int32
+	awaiter    BatchAwaiter
+
+	settled  bool
+	response *sdk.CapabilityResponse
+	err      error
+}
+
+func (c *capabilityPromise) Await() (*sdk.CapabilityResponse, error) {
+	if !c.settled {
+		responses, err := c.awaiter.AwaitCapabilities([]int32{c.callbackID})
+		c.settle(responses, err)
+	}
+	return c.response, c.err
+}
+
+func (c *capabilityPromise) settle(responses map[int32]*sdk.CapabilityResponse, err error) {
+	c.settled = true
+	if err != nil {
+		c.err = err
+		return
+	}
+
+	response, ok := responses[c.callbackID]
+	if !ok {
+		c.err = fmt.Errorf("cannot find response for %d", c.callbackID)
+		return
+	}
+	c.response = response
+}
+
+func (c *capabilityPromise) pendingCalls() []*capabilityPromise {
+	if c.settled {
+		return nil
+	}
+	return []*capabilityPromise{c}
+}
+
+// pendingCaller is implemented by promises that are backed by, or derived from, outstanding host calls.
+type pendingCaller interface {
+	pendingCalls() []*capabilityPromise
+}
+
+func pendingCallsOf(p any) []*capabilityPromise {
+	if pc, ok := p.(pendingCaller); ok {
+		return pc.pendingCalls()
+	}
+	return nil
+}
+
+// settleAll gathers the outstanding host calls behind the promises and settles them with one await per BatchAwaiter.
+// Awaiters are visited in the order they are first seen so that the sequence of host calls is deterministic.
+func settleAll(promises ...any) {
+	var awaiters []BatchAwaiter
+	byAwaiter := map[BatchAwaiter][]*capabilityPromise{}
+	seen := map[*capabilityPromise]bool{}
+	for _, p := range promises {
+		for _, call := range pendingCallsOf(p) {
+			if seen[call] {
+				continue
+			}
+			seen[call] = true
+			if _, ok := byAwaiter[call.awaiter]; !ok {
+				awaiters = append(awaiters, call.awaiter)
+			}
+			byAwaiter[call.awaiter] = append(byAwaiter[call.awaiter], call)
+		}
+	}
+
+	for _, awaiter := range awaiters {
+		calls := byAwaiter[awaiter]
+		ids := make([]int32, len(calls))
+		for i, call := range calls {
+			ids[i] = call.callbackID
+		}
+
+		responses, err := awaiter.AwaitCapabilities(ids)
+		for _, call := range calls {
+			call.settle(responses, err)
+		}
+	}
+}
+
+// collectErrs returns errs if any of them is non-nil, otherwise nil.
+func collectErrs(errs ...error) []error {
+	for _, err := range errs {
+		if err != nil {
+			return errs
+		}
+	}
+	return nil
+}
+
+// AwaitAll awaits all the promises, settling the capability calls behind them in a single host round-trip.
+// Promises derived from capability calls with Then or ThenPromise are supported; only the calls they were derived from are batched.
+// The results and errors are returned in the same order as the promises.
+// The returned error slice is nil when every promise succeeded.
+func AwaitAll[T any](promises ...Promise[T]) ([]T, []error) {
+	asAny := make([]any, len(promises))
+	for i, p := range promises {
+		asAny[i] = p
+	}
+	settleAll(asAny...)
+
+	results := make([]T, len(promises))
+	errs := make([]error, len(promises))
+	for i, p := range promises {
+		results[i], errs[i] = p.Await()
+	}
+	return results, collectErrs(errs...)
+}
+
+// AwaitAll2 is like AwaitAll, but for two promises of different types.
+func AwaitAll2[A, B any](pa Promise[A], pb Promise[B]) (A, B, []error) {
+	settleAll(pa, pb)
+	a, errA := pa.Await()
+	b, errB := pb.Await()
+	return a, b, collectErrs(errA, errB)
+}
+
+// AwaitAll3 is like AwaitAll, but for three promises of different types.
+func AwaitAll3[A, B, C any](pa Promise[A], pb Promise[B], pc Promise[C]) (A, B, C, []error) {
+	settleAll(pa, pb, pc)
+	a, errA := pa.Await()
+	b, errB := pb.Await()
+	c, errC := pc.Await()
+	return a, b, c, collectErrs(errA, errB, errC)
+}
+
+// AwaitAll4 is like AwaitAll, but for four promises of different types.
+func AwaitAll4[A, B, C, D any](pa Promise[A], pb Promise[B], pc Promise[C], pd Promise[D]) (A, B, C, D, []error) {
+	settleAll(pa, pb, pc, pd)
+	a, errA := pa.Await()
+	b, errB := pb.Await()
+	c, errC := pc.Await()
+	d, errD := pd.Await()
+	return a, b, c, d, collectErrs(errA, errB, errC, errD)
+}
+
+// AwaitAll5 is like AwaitAll, but for five promises of different types.
+func AwaitAll5[A, B, C, D, E any](pa Promise[A], pb Promise[B], pc Promise[C], pd Promise[D], pe Promise[E]) (A, B, C, D, E, []error) {
+	settleAll(pa, pb, pc, pd, pe)
+	a, errA := pa.Await()
+	b, errB := pb.Await()
+	c, errC := pc.Await()
+	d, errD := pd.Await()
+	e, errE := pe.Await()
+	return a, b, c, d, e, collectErrs(errA, errB, errC, errD, errE)
+}
@@ -0,0 +1,153 @@
+package cre_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+type fakeBatchAwaiter struct {
+	responses map[int32]*sdk.CapabilityResponse
+	err       error
+	calls     [][]int32
+}
+
+func (f *fakeBatchAwaiter) AwaitCapabilities(ids []int32) (map[int32]*sdk.CapabilityResponse, error) {
+	f.calls = append(f.calls, ids)
+	return f.responses, f.err
+}
+
+func errorResponse(msg string) *sdk.CapabilityResponse {
+	return &sdk.CapabilityResponse{Response: &sdk.CapabilityResponse_Error{Error: msg}}
+}
+
+func responseError(r *sdk.CapabilityResponse) (string, error) {
+	return r.GetError(), nil
+}
+
+func TestAwaitAll(t *testing.T) {
+	t.Run("settles all calls in one await", func(t *testing.T) {
+		awaiter := &fakeBatchAwaiter{responses: map[int32]*sdk.CapabilityResponse{
+			1: errorResponse("one"),
+			2: errorResponse("two"),
+			3: errorResponse("three"),
+		}}
+
+		promises := []cre.Promise[string]{
+			cre.Then(cre.NewCapabilityPromise(1, awaiter), responseError),
+			cre.Then(cre.NewCapabilityPromise(2, awaiter), responseError),
+			cre.Then(cre.NewCapabilityPromise(3, awaiter), responseError),
+		}
+
+		results, errs := cre.AwaitAll(promises...)
+		assert.Nil(t, errs)
+		assert.Equal(t, []string{"one", "two", "three"}, results)
+		assert.Equal(t, [][]int32{{1, 2, 3}}, awaiter.calls)
+
+		// Awaiting again does not call the host.
+		result, err := promises[1].Await()
+		require.NoError(t, err)
+		assert.Equal(t, "two", result)
+		assert.Len(t, awaiter.calls, 1)
+	})
+
+	t.Run("returns per promise errors", func(t *testing.T) {
+		awaiter := &fakeBatchAwaiter{responses: map[int32]*sdk.CapabilityResponse{1: errorResponse("one")}}
+		fnErr := errors.New("fn failed")
+
+		results, errs := cre.AwaitAll(
+			cre.Then(cre.NewCapabilityPromise(1, awaiter), responseError),
+			cre.Then(cre.NewCapabilityPromise(2, awaiter), responseError),
+			cre.Then(cre.NewCapabilityPromise(1, awaiter), func(*sdk.CapabilityResponse) (string, error) {
+				return "", fnErr
+			}),
+		)
+
+		require.Len(t, errs, 3)
+		assert.NoError(t, errs[0])
+		assert.ErrorContains(t, errs[1], "cannot find response for 2")
+		assert.ErrorIs(t, errs[2], fnErr)
+		assert.Equal(t, "one", results[0])
+		assert.Equal(t, [][]int32{{1, 2, 1}}, awaiter.calls)
+	})
+
+	t.Run("await error is returned for every pending call", func(t *testing.T) {
+		awaitErr := errors.New("host failure")
+		awaiter := &fakeBatchAwaiter{err: awaitErr}
+
+		_, errs := cre.AwaitAll(cre.NewCapabilityPromise(1, awaiter), cre.NewCapabilityPromise(2, awaiter))
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], awaitErr)
+		assert.ErrorIs(t, errs[1], awaitErr)
+		assert.Len(t, awaiter.calls, 1)
+	})
+
+	t.Run("settled and non capability promises are not re-awaited", func(t *testing.T) {
+		awaiter := &fakeBatchAwaiter{responses: map[int32]*sdk.CapabilityResponse{1: errorResponse("one"), 2: errorResponse("two")}}
+		settled := cre.NewCapabilityPromise(1, awaiter)
+		_, err := settled.Await()
+		require.NoError(t, err)
+
+		results, errs := cre.AwaitAll(
+			cre.Then(settled, responseError),
+			cre.PromiseFromResult("constant", nil),
+			cre.Then(cre.NewCapabilityPromise(2, awaiter), responseError),
+		)
+		assert.Nil(t, errs)
+		assert.Equal(t, []string{"one", "constant", "two"}, results)
+		assert.Equal(t, [][]int32{{1}, {2}}, awaiter.calls)
+	})
+
+	t.Run("no promises", func(t *testing.T) {
+		results, errs := cre.AwaitAll[int]()
+		assert.Empty(t, results)
+		assert.Nil(t, errs)
+	})
+}
+
+func TestAwaitAll2(t *testing.T) {
+	awaiter := &fakeBatchAwaiter{responses: map[int32]*sdk.CapabilityResponse{1: errorResponse("one"), 2: errorResponse("2")}}
+	pa := cre.Then(cre.NewCapabilityPromise(1, awaiter), responseError)
+	pb := cre.Then(cre.NewCapabilityPromise(2, awaiter), func(r *sdk.CapabilityResponse) (int, error) {
+		return len(r.GetError()), nil
+	})
+
+	a, b, errs := cre.AwaitAll2(pa, pb)
+	assert.Nil(t, errs)
+	assert.Equal(t, "one", a)
+	assert.Equal(t, 1, b)
+	assert.Equal(t, [][]int32{{1, 2}}, awaiter.calls)
+}
+
+func TestZipSettlesCallsTogether(t *testing.T) {
+	awaiter := &fakeBatchAwaiter{responses: map[int32]*sdk.CapabilityResponse{1: errorResponse("one"), 2: errorResponse("two")}}
+	zipped := cre.Zip(
+		cre.Then(cre.NewCapabilityPromise(1, awaiter), responseError),
+		cre.Then(cre.NewCapabilityPromise(2, awaiter), responseError),
+	)
+
+	result, err := zipped.Await()
+	require.NoError(t, err)
+	assert.Equal(t, cre.Pair[string, string]{First: "one", Second: "two"}, result)
+	assert.Equal(t, [][]int32{{1, 2}}, awaiter.calls)
+}
+
+func TestFirstSuccessfulSettlesCallsTogether(t *testing.T) {
+	awaiter := &fakeBatchAwaiter{responses: map[int32]*sdk.CapabilityResponse{1: errorResponse("one"), 2: errorResponse("two")}}
+	first := cre.FirstSuccessful(
+		cre.Then(cre.NewCapabilityPromise(1, awaiter), func(r *sdk.CapabilityResponse) (string, error) {
+			return "", errors.New(r.GetError())
+		}),
+		cre.Then(cre.NewCapabilityPromise(2, awaiter), responseError),
+	)
+
+	result, err := first.Await()
+	require.NoError(t, err)
+	assert.Equal(t, "two", result)
+	assert.Equal(t, [][]int32{{1, 2}}, awaiter.calls)
+}
@@ -31,9 +31,10 @@ func ConsensusMedianAggregation[T NumericType]() ConsensusAggregation[T] {
 
 // ConsensusIdenticalAggregation is used when responses from each node are expected to be identical.
 // The aggregation tolerates up to F faulty nodes returning errors or different values.
+// When T is a proto message, the deterministic encodings of the messages must be identical.
 func ConsensusIdenticalAggregation[T any]() ConsensusAggregation[T] {
 	var t T
-	if isIdenticalType(reflect.TypeOf(t)) {
+	if isProtoMessage(reflect.TypeOf(t)) || isIdenticalType(reflect.TypeOf(t)) {
 		return &consensusDescriptor[T]{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: sdk.AggregationType_AGGREGATION_TYPE_IDENTICAL}}
 	}
 
@@ -138,13 +139,8 @@ func parseConsensusTagDepth(t reflect.Type, path string, depth int) (*sdk.Consen
 			return nil, fmt.Errorf("unexported field %s with consensus tag on type %s accessed via %s", field.Name, t.Name(), path)
 		}
 
-		serializedName := field.Name
-		mapstructureTagParts := strings.Split(field.Tag.Get("mapstructure"), ",")
-		if mapstructureTagParts[0] != "" {
-			serializedName = mapstructureTagParts[0]
-		}
-
-		if len(mapstructureTagParts) > 1 && mapstructureTagParts[1] == "squash" {
+		serializedName, squash := mapstructureName(field)
+		if squash {
 			inner, err := parseConsensusTagDepth(field.Type, path+field.Name+".", depth+1)
 			if err != nil {
 				return nil, fmt.Errorf("nested field %s: %w", field.Name, err)
@@ -156,40 +152,18 @@ func parseConsensusTagDepth(t reflect.Type, path string, depth int) (*sdk.Consen
 			continue
 		}
 
-		tpe := field.Type
-		if tpe.Kind() == reflect.Pointer && tpe != bigIntType {
-			tpe = tpe.Elem()
-		}
-
 		var err error
 		switch tag {
-		case "median":
-			if !isNumeric(tpe) {
-				return nil, fmt.Errorf("field %s marked as median but is not a numeric type", field.Name)
-			}
-			descriptors[serializedName] = &sdk.ConsensusDescriptor{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: sdk.AggregationType_AGGREGATION_TYPE_MEDIAN}}
-		case "identical":
-			if !isIdenticalType(tpe) {
-				return nil, fmt.Errorf("field %s marked as identical but is not a valid type", field.Name)
-			}
-			descriptors[serializedName] = &sdk.ConsensusDescriptor{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: sdk.AggregationType_AGGREGATION_TYPE_IDENTICAL}}
-		case "common_prefix":
-			if !isIdenticalSliceOrArray(tpe) {
-				return nil, fmt.Errorf("field %s marked as common_prefix but is not slice/array", field.Name)
-			}
-			descriptors[serializedName] = &sdk.ConsensusDescriptor{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: sdk.AggregationType_AGGREGATION_TYPE_COMMON_PREFIX}}
-		case "common_suffix":
-			if !isIdenticalSliceOrArray(field.Type) {
-				return nil, fmt.Errorf("field %s marked as common_suffix but is not slice/array", field.Name)
-			}
-			descriptors[serializedName] = &sdk.ConsensusDescriptor{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: sdk.AggregationType_AGGREGATION_TYPE_COMMON_SUFFIX}}
 		case "nested":
 			descriptors[serializedName], err = parseConsensusTagDepth(field.Type, path+field.Name+".", depth+1)
 			if err != nil {
 				return nil, fmt.Errorf("nested field %s: %w", field.Name, err)
 			}
 		default:
-			return nil, fmt.Errorf("unknown consensus tag: %s on field %s", tag, field.Name)
+			descriptors[serializedName], err = fieldAggregationDescriptor(tag, field)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -200,6 +174,49 @@ func parseConsensusTagDepth(t reflect.Type, path string, depth int) (*sdk.Consen
 	}, nil
 }
 
+// mapstructureName returns the name a field is serialized under, and whether its fields are squashed into the parent.
+func mapstructureName(field reflect.StructField) (string, bool) {
+	serializedName := field.Name
+	mapstructureTagParts := strings.Split(field.Tag.Get("mapstructure"), ",")
+	if mapstructureTagParts[0] != "" {
+		serializedName = mapstructureTagParts[0]
+	}
+	return serializedName, len(mapstructureTagParts) > 1 && mapstructureTagParts[1] == "squash"
+}
+
+// fieldAggregationDescriptor returns the descriptor for a field aggregated as a whole, such as `median` or `identical`.
+func fieldAggregationDescriptor(aggregation string, field reflect.StructField) (*sdk.ConsensusDescriptor, error) {
+	tpe := field.Type
+	if tpe.Kind() == reflect.Pointer && tpe != bigIntType {
+		tpe = tpe.Elem()
+	}
+
+	switch aggregation {
+	case "median":
+		if !isNumeric(tpe) {
+			return nil, fmt.Errorf("field %s marked as median but is not a numeric type", field.Name)
+		}
+		return &sdk.ConsensusDescriptor{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: sdk.AggregationType_AGGREGATION_TYPE_MEDIAN}}, nil
+	case "identical":
+		if !isIdenticalType(tpe) {
+			return nil, fmt.Errorf("field %s marked as identical but is not a valid type", field.Name)
+		}
+		return &sdk.ConsensusDescriptor{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: sdk.AggregationType_AGGREGATION_TYPE_IDENTICAL}}, nil
+	case "common_prefix":
+		if !isIdenticalSliceOrArray(tpe) {
+			return nil, fmt.Errorf("field %s marked as common_prefix but is not slice/array", field.Name)
+		}
+		return &sdk.ConsensusDescriptor{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: sdk.AggregationType_AGGREGATION_TYPE_COMMON_PREFIX}}, nil
+	case "common_suffix":
+		if !isIdenticalSliceOrArray(field.Type) {
+			return nil, fmt.Errorf("field %s marked as common_suffix but is not slice/array", field.Name)
+		}
+		return &sdk.ConsensusDescriptor{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: sdk.AggregationType_AGGREGATION_TYPE_COMMON_SUFFIX}}, nil
+	default:
+		return nil, fmt.Errorf("unknown consensus tag: %s on field %s", aggregation, field.Name)
+	}
+}
+
 func isIdenticalSliceOrArray(t reflect.Type) bool {
 	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && isIdenticalType(t.Elem())
 }
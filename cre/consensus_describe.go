@@ -0,0 +1,275 @@
+package cre
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// AggregationFields is the aggregation of a value, or field, whose fields are aggregated individually.
+	AggregationFields = "fields"
+
+	// AggregationIgnored is the aggregation of a field that is excluded from consensus.
+	AggregationIgnored = "ignored"
+)
+
+// AggregationDescription describes how a ConsensusAggregation aggregates values, see DescribeAggregation.
+// It can be rendered as text with String, or as JSON with encoding/json.
+type AggregationDescription struct {
+	// Aggregation is how the value is aggregated as a whole, such as "median", or AggregationFields.
+	Aggregation string `json:"aggregation"`
+
+	// Default is the default value, or nil when there is none.
+	Default any `json:"default,omitempty"`
+
+	// Fields describes every field, in declaration order, when Aggregation is AggregationFields.
+	// Nested fields follow the field they are nested in.
+	Fields []FieldAggregationDescription `json:"fields,omitempty"`
+}
+
+// FieldAggregationDescription describes how a single field is aggregated.
+type FieldAggregationDescription struct {
+	// Path is the path to the field using Go field names, such as "Meta.Source".
+	// For proto messages, proto field names are used.
+	Path string `json:"path"`
+
+	// SerializedName is the path to the field in the observation sent to consensus, using mapstructure names.
+	SerializedName string `json:"serializedName"`
+
+	// Aggregation is how the field is aggregated, such as "median", AggregationFields, or AggregationIgnored.
+	Aggregation string `json:"aggregation"`
+
+	// Default is the field's value in the default value, or nil when there is no default value.
+	Default any `json:"default,omitempty"`
+}
+
+// DescribeAggregation describes the descriptor tree of a ConsensusAggregation.
+// Fields that are not part of the descriptor, and are therefore cleared from observations, are reported as AggregationIgnored.
+// It returns the ConsensusAggregation's error, if any.
+func DescribeAggregation[T any](ca ConsensusAggregation[T]) (*AggregationDescription, error) {
+	if err := ca.Err(); err != nil {
+		return nil, err
+	}
+
+	var defaultValue reflect.Value
+	description := &AggregationDescription{}
+	if d := ca.Default(); d != nil {
+		description.Default = *d
+		defaultValue = reflect.ValueOf(d).Elem()
+	}
+
+	descriptor := ca.Descriptor()
+	fieldsMap := descriptor.GetFieldsMap()
+	if fieldsMap == nil {
+		description.Aggregation = aggregationName(descriptor.GetAggregation())
+		return description, nil
+	}
+
+	description.Aggregation = AggregationFields
+	var zero T
+	t := reflect.TypeOf(zero)
+	switch {
+	case isProtoMessage(t):
+		var msg protoreflect.Message
+		if m, ok := description.Default.(proto.Message); ok {
+			msg = m.ProtoReflect()
+		}
+		describeProtoFields(any(zero).(proto.Message).ProtoReflect().Descriptor(), msg, fieldsMap, "", &description.Fields)
+	case t != nil && (t.Kind() == reflect.Struct || t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct):
+		describeStructFields(t, defaultValue, fieldsMap, "", "", &description.Fields, 0)
+	default:
+		describeFieldsMap(fieldsMap, "", &description.Fields)
+	}
+	return description, nil
+}
+
+// String renders the description with one line per field.
+func (d *AggregationDescription) String() string {
+	return strings.Join(d.lines(true), "\n")
+}
+
+// Layout renders the description like String, but without default values, one line per field.
+// It is meant to be compared against an expected layout in tests.
+func (d *AggregationDescription) Layout() []string {
+	return d.lines(false)
+}
+
+func (d *AggregationDescription) lines(withDefaults bool) []string {
+	if d.Aggregation != AggregationFields {
+		return []string{describeLine(d.Aggregation, d.Default, withDefaults)}
+	}
+
+	lines := make([]string, len(d.Fields))
+	for i, field := range d.Fields {
+		name := field.Path
+		if field.SerializedName != field.Path {
+			name += " as " + field.SerializedName
+		}
+		lines[i] = name + ": " + describeLine(field.Aggregation, field.Default, withDefaults && field.Aggregation != AggregationFields)
+	}
+	return lines
+}
+
+func describeLine(aggregation string, defaultValue any, withDefault bool) string {
+	if !withDefault || defaultValue == nil {
+		return aggregation
+	}
+	if s, ok := defaultValue.(string); ok {
+		return fmt.Sprintf("%s (default %q)", aggregation, s)
+	}
+	return fmt.Sprintf("%s (default %v)", aggregation, defaultValue)
+}
+
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+func aggregationName(aggregationType sdk.AggregationType) string {
+	for name, t := range aggregationTypes {
+		if t == aggregationType {
+			return name
+		}
+	}
+	return aggregationType.String()
+}
+
+func describeStructFields(t reflect.Type, value reflect.Value, fieldsMap *sdk.FieldsMap, path, serializedPath string, out *[]FieldAggregationDescription, depth int) {
+	if depth >= maxConsensusDepth {
+		return
+	}
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		if value.IsValid() {
+			value = value.Elem()
+		}
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		var fieldValue reflect.Value
+		if value.IsValid() {
+			fieldValue = value.Field(i)
+		}
+
+		serializedName, squash := mapstructureName(field)
+		if squash {
+			// Fields of embedded structs are promoted, so they are referred to without the embedded struct's name.
+			squashedPath := path
+			if !field.Anonymous {
+				squashedPath += field.Name + "."
+			}
+			describeStructFields(field.Type, fieldValue, fieldsMap, squashedPath, serializedPath, out, depth+1)
+			continue
+		}
+
+		description := FieldAggregationDescription{
+			Path:           path + field.Name,
+			SerializedName: serializedPath + serializedName,
+			Aggregation:    AggregationIgnored,
+		}
+		if fieldValue.IsValid() && !isNilValue(fieldValue) {
+			description.Default = fieldValue.Interface()
+		}
+
+		descriptor, ok := fieldsMap.GetFields()[serializedName]
+		if !ok {
+			*out = append(*out, description)
+			continue
+		}
+
+		nested := descriptor.GetFieldsMap()
+		if nested == nil {
+			description.Aggregation = aggregationName(descriptor.GetAggregation())
+			*out = append(*out, description)
+			continue
+		}
+
+		description.Aggregation = AggregationFields
+		*out = append(*out, description)
+		if fieldValue.IsValid() && isNilValue(fieldValue) {
+			fieldValue = reflect.Value{}
+		}
+		describeStructFields(field.Type, fieldValue, nested, description.Path+".", description.SerializedName+".", out, depth+1)
+	}
+}
+
+func describeProtoFields(md protoreflect.MessageDescriptor, msg protoreflect.Message, fieldsMap *sdk.FieldsMap, path string, out *[]FieldAggregationDescription) {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := string(fd.Name())
+		description := FieldAggregationDescription{
+			Path:           path + name,
+			SerializedName: path + name,
+			Aggregation:    AggregationIgnored,
+		}
+
+		var nestedMsg protoreflect.Message
+		if msg != nil && msg.Has(fd) {
+			description.Default = protoFieldToAny(fd, msg.Get(fd))
+			if fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+				nestedMsg = msg.Get(fd).Message()
+			}
+		}
+
+		descriptor, ok := fieldsMap.GetFields()[name]
+		if !ok {
+			*out = append(*out, description)
+			continue
+		}
+
+		nested := descriptor.GetFieldsMap()
+		if nested == nil {
+			description.Aggregation = aggregationName(descriptor.GetAggregation())
+			*out = append(*out, description)
+			continue
+		}
+
+		description.Aggregation = AggregationFields
+		*out = append(*out, description)
+		if fd.Message() != nil {
+			describeProtoFields(fd.Message(), nestedMsg, nested, description.Path+".", out)
+		}
+	}
+}
+
+// describeFieldsMap describes the fields of a value that is neither a struct nor a proto message, such as a map, in name order.
+func describeFieldsMap(fieldsMap *sdk.FieldsMap, path string, out *[]FieldAggregationDescription) {
+	names := make([]string, 0, len(fieldsMap.GetFields()))
+	for name := range fieldsMap.GetFields() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		descriptor := fieldsMap.GetFields()[name]
+		description := FieldAggregationDescription{Path: path + name, SerializedName: path + name}
+		if nested := descriptor.GetFieldsMap(); nested != nil {
+			description.Aggregation = AggregationFields
+			*out = append(*out, description)
+			describeFieldsMap(nested, description.Path+".", out)
+			continue
+		}
+		description.Aggregation = aggregationName(descriptor.GetAggregation())
+		*out = append(*out, description)
+	}
+}
@@ -0,0 +1,110 @@
+package cre_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+func TestDescribeAggregation(t *testing.T) {
+	t.Run("struct from tags", func(t *testing.T) {
+		description, err := cre.DescribeAggregation(cre.ConsensusAggregationFromTags[taggedPrice]())
+		require.NoError(t, err)
+
+		assert.Equal(t, cre.AggregationFields, description.Aggregation)
+		assert.Nil(t, description.Default)
+		assert.Equal(t, `Round: median
+Price: median
+Symbol as symbol: identical
+Meta: fields
+Meta.Source: identical
+Meta.Sources: common_prefix
+Debug: ignored`, description.String())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		defaultValue := taggedPrice{
+			TaggedBase: TaggedBase{Round: 3},
+			Price:      big.NewInt(100),
+			Symbol:     "ETH",
+			Meta:       &taggedMeta{Source: "source"},
+		}
+		description, err := cre.DescribeAggregation(cre.ConsensusAggregationFromTags[taggedPrice]().WithDefault(defaultValue))
+		require.NoError(t, err)
+
+		assert.Equal(t, defaultValue, description.Default)
+		assert.Equal(t, `Round: median (default 3)
+Price: median (default 100)
+Symbol as symbol: identical (default "ETH")
+Meta: fields
+Meta.Source: identical (default "source")
+Meta.Sources: common_prefix
+Debug: ignored (default "")`, description.String())
+		assert.Equal(t, []string{
+			"Round: median",
+			"Price: median",
+			"Symbol as symbol: identical",
+			"Meta: fields",
+			"Meta.Source: identical",
+			"Meta.Sources: common_prefix",
+			"Debug: ignored",
+		}, description.Layout())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		description, err := cre.DescribeAggregation(cre.Fields[untaggedMeta]().Identical("Source").Ignore("Sources").WithDefault(untaggedMeta{Source: "a"}))
+		require.NoError(t, err)
+
+		encoded, err := json.Marshal(description)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"aggregation": "fields",
+			"default": {"Source": "a", "Sources": null},
+			"fields": [
+				{"path": "Source", "serializedName": "Source", "aggregation": "identical", "default": "a"},
+				{"path": "Sources", "serializedName": "Sources", "aggregation": "ignored"}
+			]
+		}`, string(encoded))
+	})
+
+	t.Run("primitive", func(t *testing.T) {
+		description, err := cre.DescribeAggregation(cre.ConsensusMedianAggregation[int]().WithDefault(7))
+		require.NoError(t, err)
+		assert.Equal(t, "median (default 7)", description.String())
+		assert.Empty(t, description.Fields)
+	})
+
+	t.Run("proto message", func(t *testing.T) {
+		aggregation := cre.Fields[*apipb.Api]().
+			Identical("name").
+			Nested("source_context", cre.Fields[*sourcecontextpb.SourceContext]().Identical("file_name")).
+			Ignore("methods", "options", "version", "mixins", "syntax", "edition")
+
+		description, err := cre.DescribeAggregation(aggregation.WithDefault(&apipb.Api{Name: "api"}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"name: identical",
+			"methods: ignored",
+			"options: ignored",
+			"version: ignored",
+			"source_context: fields",
+			"source_context.file_name: identical",
+			"mixins: ignored",
+			"syntax: ignored",
+			"edition: ignored",
+		}, description.Layout())
+		assert.Equal(t, "api", description.Fields[0].Default)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := cre.DescribeAggregation(cre.ConsensusIdenticalAggregation[chan int]())
+		assert.Error(t, err)
+	})
+}
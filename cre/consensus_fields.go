@@ -0,0 +1,321 @@
+package cre
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NestedAggregation describes how to aggregate the fields of a nested struct, see FieldsBuilder.Nested.
+// It is implemented by FieldsBuilder and by the ConsensusAggregation returned from ConsensusAggregationFromTags.
+type NestedAggregation interface {
+	Descriptor() *sdk.ConsensusDescriptor
+	Err() error
+}
+
+// FieldsBuilder builds a ConsensusAggregation for the struct T without requiring `consensus_aggregation` tags on T.
+// This allows aggregating types you do not own, such as generated protobuf structs.
+// Fields are referred to by their Go name, including fields promoted from a struct squashed with `mapstructure:",squash"`.
+// The resulting descriptor is the same one ConsensusAggregationFromTags produces for the equivalent tags,
+// so fields are serialized using their `mapstructure` names.
+// As with tags, every exported field must be aggregated or ignored.
+// When T is a proto message, fields are instead referred to, and serialized, by their proto field name,
+// and every field of the message must be aggregated or ignored.
+// Errors are reported by Err when the FieldsBuilder is used as a ConsensusAggregation.
+type FieldsBuilder[T any] struct {
+	structType   reflect.Type
+	message      protoreflect.MessageDescriptor
+	aggregations map[string]fieldAggregation
+	errs         []error
+}
+
+type fieldAggregation struct {
+	aggregation string
+	nested      NestedAggregation
+}
+
+var _ ConsensusAggregation[struct{}] = (*FieldsBuilder[struct{}])(nil)
+
+// Fields starts building a ConsensusAggregation for the struct T, or the struct T points to.
+func Fields[T any]() *FieldsBuilder[T] {
+	b := &FieldsBuilder[T]{aggregations: map[string]fieldAggregation{}}
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil {
+		b.errs = append(b.errs, errors.New("Fields expects a non-nil type"))
+		return b
+	}
+	if msg, ok := any(zero).(proto.Message); ok {
+		b.message = msg.ProtoReflect().Descriptor()
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		b.errs = append(b.errs, fmt.Errorf("Fields expects a struct type, got %s", t.Kind()))
+		return b
+	}
+	b.structType = t
+	return b
+}
+
+// Median aggregates the numeric fields by taking the median in the same manner as ConsensusMedianAggregation.
+func (b *FieldsBuilder[T]) Median(fields ...string) *FieldsBuilder[T] {
+	return b.set("median", nil, fields)
+}
+
+// Identical requires the fields to be identical across nodes in the same manner as ConsensusIdenticalAggregation.
+func (b *FieldsBuilder[T]) Identical(fields ...string) *FieldsBuilder[T] {
+	return b.set("identical", nil, fields)
+}
+
+// CommonPrefix aggregates the slice or array fields by taking their longest common prefix.
+func (b *FieldsBuilder[T]) CommonPrefix(fields ...string) *FieldsBuilder[T] {
+	return b.set("common_prefix", nil, fields)
+}
+
+// CommonSuffix aggregates the slice or array fields by taking their longest common suffix.
+func (b *FieldsBuilder[T]) CommonSuffix(fields ...string) *FieldsBuilder[T] {
+	return b.set("common_suffix", nil, fields)
+}
+
+// Nested aggregates the fields of a nested struct field using another aggregation, typically built with Fields.
+func (b *FieldsBuilder[T]) Nested(field string, aggregation NestedAggregation) *FieldsBuilder[T] {
+	return b.set("nested", aggregation, []string{field})
+}
+
+// Ignore excludes the fields from consensus. Ignored fields are cleared from observations.
+func (b *FieldsBuilder[T]) Ignore(fields ...string) *FieldsBuilder[T] {
+	return b.set("ignore", nil, fields)
+}
+
+func (b *FieldsBuilder[T]) set(aggregation string, nested NestedAggregation, fields []string) *FieldsBuilder[T] {
+	if b.structType == nil {
+		return b
+	}
+
+	for _, name := range fields {
+		if existing, ok := b.aggregations[name]; ok {
+			b.errs = append(b.errs, fmt.Errorf("field %s is already marked as %s", name, existing.aggregation))
+			continue
+		}
+
+		if err := b.checkField(name, aggregation, nested); err != nil {
+			b.errs = append(b.errs, err)
+			continue
+		}
+
+		b.aggregations[name] = fieldAggregation{aggregation: aggregation, nested: nested}
+	}
+	return b
+}
+
+func (b *FieldsBuilder[T]) checkField(name, aggregation string, nested NestedAggregation) error {
+	if b.message != nil {
+		fd := b.message.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("message %s has no field %s", b.message.FullName(), name)
+		}
+		return checkProtoFieldAggregation(fd, aggregation, nested)
+	}
+
+	field, ok := findField(b.structType, name, 0)
+	if !ok {
+		return fmt.Errorf("type %s has no exported field %s", b.structType.Name(), name)
+	}
+	return checkFieldAggregation(field, aggregation, nested)
+}
+
+func (b *FieldsBuilder[T]) Descriptor() *sdk.ConsensusDescriptor {
+	return b.build().Descriptor()
+}
+
+func (b *FieldsBuilder[T]) Default() *T {
+	return nil
+}
+
+func (b *FieldsBuilder[T]) Err() error {
+	return b.build().Err()
+}
+
+func (b *FieldsBuilder[T]) WithDefault(t T) ConsensusAggregation[T] {
+	return b.build().WithDefault(t)
+}
+
+func (b *FieldsBuilder[T]) WithValidator(validator func(T) error) ConsensusAggregation[T] {
+	return b.build().WithValidator(validator)
+}
+
+func (b *FieldsBuilder[T]) aggregatedType() reflect.Type {
+	return b.structType
+}
+
+func (b *FieldsBuilder[T]) aggregatedMessage() protoreflect.MessageDescriptor {
+	return b.message
+}
+
+func (b *FieldsBuilder[T]) build() ConsensusAggregation[T] {
+	if len(b.errs) > 0 {
+		return &consensusDescriptorError[T]{Error: errors.Join(b.errs...)}
+	}
+
+	descriptors := map[string]*sdk.ConsensusDescriptor{}
+	var err error
+	if b.message != nil {
+		err = b.collectProto(descriptors)
+	} else {
+		err = b.collect(b.structType, "", descriptors, 0)
+	}
+	if err != nil {
+		return &consensusDescriptorError[T]{Error: err}
+	}
+
+	return &consensusDescriptor[T]{
+		Descriptor_: &sdk.ConsensusDescriptor_FieldsMap{
+			FieldsMap: &sdk.FieldsMap{Fields: descriptors},
+		},
+	}
+}
+
+// collect adds the descriptors of t's fields, and of the fields of any struct squashed into t, to descriptors.
+func (b *FieldsBuilder[T]) collect(t reflect.Type, path string, descriptors map[string]*sdk.ConsensusDescriptor, depth int) error {
+	if depth >= maxConsensusDepth {
+		return fmt.Errorf("Fields exceeded max recursion depth %d at %s", maxConsensusDepth, path)
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		configured, ok := b.aggregations[field.Name]
+		serializedName, squash := mapstructureName(field)
+		if !ok && squash {
+			inner := field.Type
+			if inner.Kind() == reflect.Pointer {
+				inner = inner.Elem()
+			}
+			if err := b.collect(inner, path+field.Name+".", descriptors, depth+1); err != nil {
+				return fmt.Errorf("nested field %s: %w", field.Name, err)
+			}
+			continue
+		}
+
+		if !ok {
+			return fmt.Errorf("missing consensus aggregation on type %s accessed via %s", t.Name(), path+field.Name)
+		}
+
+		switch configured.aggregation {
+		case "ignore":
+		case "nested":
+			if err := configured.nested.Err(); err != nil {
+				return fmt.Errorf("nested field %s: %w", field.Name, err)
+			}
+			descriptors[serializedName] = configured.nested.Descriptor()
+		default:
+			descriptor, err := fieldAggregationDescriptor(configured.aggregation, field)
+			if err != nil {
+				return err
+			}
+			descriptors[serializedName] = descriptor
+		}
+	}
+
+	return nil
+}
+
+// collectProto adds the descriptors of the message's fields to descriptors, keyed by proto field name.
+func (b *FieldsBuilder[T]) collectProto(descriptors map[string]*sdk.ConsensusDescriptor) error {
+	fields := b.message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		name := string(fields.Get(i).Name())
+		configured, ok := b.aggregations[name]
+		if !ok {
+			return fmt.Errorf("missing consensus aggregation on message %s accessed via %s", b.message.FullName(), name)
+		}
+
+		switch configured.aggregation {
+		case "ignore":
+		case "nested":
+			if err := configured.nested.Err(); err != nil {
+				return fmt.Errorf("nested field %s: %w", name, err)
+			}
+			descriptors[name] = configured.nested.Descriptor()
+		default:
+			descriptors[name] = &sdk.ConsensusDescriptor{Descriptor_: &sdk.ConsensusDescriptor_Aggregation{Aggregation: aggregationTypes[configured.aggregation]}}
+		}
+	}
+
+	return nil
+}
+
+var aggregationTypes = map[string]sdk.AggregationType{
+	"median":        sdk.AggregationType_AGGREGATION_TYPE_MEDIAN,
+	"identical":     sdk.AggregationType_AGGREGATION_TYPE_IDENTICAL,
+	"common_prefix": sdk.AggregationType_AGGREGATION_TYPE_COMMON_PREFIX,
+	"common_suffix": sdk.AggregationType_AGGREGATION_TYPE_COMMON_SUFFIX,
+}
+
+// findField finds an exported field of t by its Go name, looking into squashed structs when t has no such field.
+func findField(t reflect.Type, name string, depth int) (reflect.StructField, bool) {
+	if depth >= maxConsensusDepth {
+		return reflect.StructField{}, false
+	}
+
+	if field, ok := t.FieldByName(name); ok && len(field.Index) == 1 && field.IsExported() {
+		return field, true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, squash := mapstructureName(field); !squash || !field.IsExported() {
+			continue
+		}
+
+		inner := field.Type
+		if inner.Kind() == reflect.Pointer {
+			inner = inner.Elem()
+		}
+		if inner.Kind() != reflect.Struct {
+			continue
+		}
+
+		if found, ok := findField(inner, name, depth+1); ok {
+			return found, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func checkFieldAggregation(field reflect.StructField, aggregation string, nested NestedAggregation) error {
+	switch aggregation {
+	case "ignore":
+		return nil
+	case "nested":
+		if nested == nil {
+			return fmt.Errorf("nested field %s has no aggregation", field.Name)
+		}
+
+		tpe := field.Type
+		if tpe.Kind() == reflect.Pointer {
+			tpe = tpe.Elem()
+		}
+		if tpe.Kind() != reflect.Struct {
+			return fmt.Errorf("field %s marked as nested but is not a struct", field.Name)
+		}
+
+		if typed, ok := nested.(interface{ aggregatedType() reflect.Type }); ok && typed.aggregatedType() != nil && typed.aggregatedType() != tpe {
+			return fmt.Errorf("nested field %s has type %s, but its aggregation is for %s", field.Name, tpe, typed.aggregatedType())
+		}
+		return nil
+	default:
+		_, err := fieldAggregationDescriptor(aggregation, field)
+		return err
+	}
+}
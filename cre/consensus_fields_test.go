@@ -0,0 +1,237 @@
+package cre_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+type untaggedMeta struct {
+	Source  string
+	Sources []string
+}
+
+type UntaggedBase struct {
+	Round int64
+}
+
+type untaggedPrice struct {
+	UntaggedBase `mapstructure:",squash"`
+	Price        *big.Int
+	Symbol       string `mapstructure:"symbol"`
+	Meta         *untaggedMeta
+	Debug        string
+	internal     chan int
+}
+
+type taggedMeta struct {
+	Source  string   `consensus_aggregation:"identical"`
+	Sources []string `consensus_aggregation:"common_prefix"`
+}
+
+type TaggedBase struct {
+	Round int64 `consensus_aggregation:"median"`
+}
+
+type taggedPrice struct {
+	TaggedBase `consensus_aggregation:"nested" mapstructure:",squash"`
+	Price      *big.Int    `consensus_aggregation:"median"`
+	Symbol     string      `consensus_aggregation:"identical" mapstructure:"symbol"`
+	Meta       *taggedMeta `consensus_aggregation:"nested"`
+	Debug      string      `consensus_aggregation:"ignore"`
+}
+
+func TestFields(t *testing.T) {
+	t.Run("matches the descriptor from tags", func(t *testing.T) {
+		meta := cre.Fields[untaggedMeta]().Identical("Source").CommonPrefix("Sources")
+		aggregation := cre.Fields[untaggedPrice]().
+			Median("Price", "Round").
+			Identical("Symbol").
+			Nested("Meta", meta).
+			Ignore("Debug")
+
+		require.NoError(t, aggregation.Err())
+		expected := cre.ConsensusAggregationFromTags[taggedPrice]()
+		require.NoError(t, expected.Err())
+		assert.True(t, proto.Equal(expected.Descriptor(), aggregation.Descriptor()))
+		assert.Nil(t, aggregation.Default())
+	})
+
+	t.Run("nested can use tags", func(t *testing.T) {
+		type outer struct {
+			Meta taggedMeta
+		}
+
+		aggregation := cre.Fields[outer]().Nested("Meta", cre.ConsensusAggregationFromTags[taggedMeta]())
+		require.NoError(t, aggregation.Err())
+		fields := aggregation.Descriptor().GetFieldsMap().GetFields()
+		require.Contains(t, fields, "Meta")
+		assert.Len(t, fields["Meta"].GetFieldsMap().GetFields(), 2)
+	})
+
+	t.Run("squashed fields", func(t *testing.T) {
+		type withSquash struct {
+			Base  UntaggedBase `mapstructure:",squash"`
+			Value int
+		}
+
+		missing := cre.Fields[withSquash]().Median("Value")
+		assert.ErrorContains(t, missing.Err(), "nested field Base: missing consensus aggregation on type UntaggedBase accessed via Base.Round")
+
+		promoted := cre.Fields[*withSquash]().Median("Value", "Round")
+		require.NoError(t, promoted.Err())
+		assert.Equal(t, sdk.AggregationType_AGGREGATION_TYPE_MEDIAN, promoted.Descriptor().GetFieldsMap().GetFields()["Round"].GetAggregation())
+
+		ignored := cre.Fields[withSquash]().Ignore("Base").Median("Value")
+		require.NoError(t, ignored.Err())
+		assert.Len(t, ignored.Descriptor().GetFieldsMap().GetFields(), 1)
+	})
+
+	t.Run("with default", func(t *testing.T) {
+		aggregation := cre.Fields[untaggedMeta]().Identical("Source", "Sources").WithDefault(untaggedMeta{Source: "default"})
+		require.NoError(t, aggregation.Err())
+		require.NotNil(t, aggregation.Default())
+		assert.Equal(t, "default", aggregation.Default().Source)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			aggregation cre.ConsensusAggregation[untaggedPrice]
+			err         string
+		}{
+			{
+				name:        "missing field",
+				aggregation: cre.Fields[untaggedPrice]().Median("Price", "Round").Identical("Symbol").Ignore("Meta"),
+				err:         "missing consensus aggregation on type untaggedPrice accessed via Debug",
+			},
+			{
+				name:        "unknown field",
+				aggregation: cre.Fields[untaggedPrice]().Median("Missing"),
+				err:         "type untaggedPrice has no exported field Missing",
+			},
+			{
+				name:        "unexported field",
+				aggregation: cre.Fields[untaggedPrice]().Identical("internal"),
+				err:         "type untaggedPrice has no exported field internal",
+			},
+			{
+				name:        "invalid type",
+				aggregation: cre.Fields[untaggedPrice]().Median("Symbol"),
+				err:         "field Symbol marked as median but is not a numeric type",
+			},
+			{
+				name:        "duplicate field",
+				aggregation: cre.Fields[untaggedPrice]().Median("Price").Ignore("Price"),
+				err:         "field Price is already marked as median",
+			},
+			{
+				name:        "nested type mismatch",
+				aggregation: cre.Fields[untaggedPrice]().Nested("Meta", cre.Fields[UntaggedBase]().Median("Round")),
+				err:         "nested field Meta has type cre_test.untaggedMeta, but its aggregation is for cre_test.UntaggedBase",
+			},
+			{
+				name:        "nested is not a struct",
+				aggregation: cre.Fields[untaggedPrice]().Nested("Symbol", cre.Fields[untaggedMeta]()),
+				err:         "field Symbol marked as nested but is not a struct",
+			},
+			{
+				name: "invalid nested aggregation",
+				aggregation: cre.Fields[untaggedPrice]().
+					Median("Price", "Round").
+					Identical("Symbol").
+					Nested("Meta", cre.Fields[untaggedMeta]().Identical("Source")).
+					Ignore("Debug"),
+				err: "nested field Meta: missing consensus aggregation on type untaggedMeta accessed via Sources",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.ErrorContains(t, tt.aggregation.Err(), tt.err)
+				assert.Nil(t, tt.aggregation.Descriptor())
+				assert.Nil(t, tt.aggregation.WithDefault(untaggedPrice{}).Default())
+			})
+		}
+	})
+
+	t.Run("non struct type", func(t *testing.T) {
+		assert.ErrorContains(t, cre.Fields[int]().Err(), "Fields expects a struct type, got int")
+	})
+}
+
+func TestFields_ProtoMessage(t *testing.T) {
+	t.Run("fields are named and serialized by proto field name", func(t *testing.T) {
+		aggregation := cre.Fields[*apipb.Api]().
+			Identical("name", "version", "syntax").
+			CommonPrefix("methods").
+			Nested("source_context", cre.Fields[*sourcecontextpb.SourceContext]().Identical("file_name")).
+			Ignore("options", "mixins", "edition")
+
+		require.NoError(t, aggregation.Err())
+		fields := aggregation.Descriptor().GetFieldsMap().GetFields()
+		assert.Len(t, fields, 5)
+		assert.Equal(t, sdk.AggregationType_AGGREGATION_TYPE_COMMON_PREFIX, fields["methods"].GetAggregation())
+		assert.Equal(t, sdk.AggregationType_AGGREGATION_TYPE_IDENTICAL, fields["source_context"].GetFieldsMap().GetFields()["file_name"].GetAggregation())
+	})
+
+	t.Run("median on numeric fields", func(t *testing.T) {
+		aggregation := cre.Fields[*wrapperspb.UInt64Value]().Median("value")
+		require.NoError(t, aggregation.Err())
+		assert.Equal(t, sdk.AggregationType_AGGREGATION_TYPE_MEDIAN, aggregation.Descriptor().GetFieldsMap().GetFields()["value"].GetAggregation())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			aggregation cre.ConsensusAggregation[*apipb.Api]
+			err         string
+		}{
+			{
+				name:        "missing field",
+				aggregation: cre.Fields[*apipb.Api]().Identical("name"),
+				err:         "missing consensus aggregation on message google.protobuf.Api accessed via methods",
+			},
+			{
+				name:        "go field name",
+				aggregation: cre.Fields[*apipb.Api]().Identical("Name"),
+				err:         "message google.protobuf.Api has no field Name",
+			},
+			{
+				name:        "median on a string",
+				aggregation: cre.Fields[*apipb.Api]().Median("version"),
+				err:         "field version marked as median but is not a numeric type",
+			},
+			{
+				name:        "common prefix on a singular field",
+				aggregation: cre.Fields[*apipb.Api]().CommonPrefix("version"),
+				err:         "field version marked as common_prefix but is not a repeated field",
+			},
+			{
+				name:        "nested on a scalar",
+				aggregation: cre.Fields[*apipb.Api]().Nested("name", cre.Fields[*sourcecontextpb.SourceContext]()),
+				err:         "field name marked as nested but is not a message",
+			},
+			{
+				name:        "nested message mismatch",
+				aggregation: cre.Fields[*apipb.Api]().Nested("source_context", cre.Fields[*wrapperspb.UInt64Value]().Median("value")),
+				err:         "nested field source_context is a google.protobuf.SourceContext, but its aggregation is not for that message",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.ErrorContains(t, tt.aggregation.Err(), tt.err)
+			})
+		}
+	})
+}
@@ -0,0 +1,399 @@
+package cre
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// wrapProtoObservation wraps a proto message observed in node mode.
+// With identical aggregation, the deterministic encoding of the message is observed, so that every field, including unknown ones, must match.
+// Otherwise, the message is converted to a map keyed by proto field name, so that fields can be aggregated individually.
+func wrapProtoObservation(m proto.Message, descriptor *sdk.ConsensusDescriptor) (values.Value, error) {
+	if descriptor.GetAggregation() == sdk.AggregationType_AGGREGATION_TYPE_IDENTICAL {
+		encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		return values.Wrap(encoded)
+	}
+
+	return values.Wrap(protoMessageToMap(m.ProtoReflect()))
+}
+
+// unwrapProtoObservation is the inverse of wrapProtoObservation, zero is only used for its type.
+func unwrapProtoObservation(zero proto.Message, v values.Value, descriptor *sdk.ConsensusDescriptor) (proto.Message, error) {
+	msg := zero.ProtoReflect().New()
+	if v == nil {
+		return msg.Interface(), nil
+	}
+
+	if descriptor.GetAggregation() == sdk.AggregationType_AGGREGATION_TYPE_IDENTICAL {
+		var encoded []byte
+		if err := v.UnwrapTo(&encoded); err != nil {
+			return nil, err
+		}
+		if err := proto.Unmarshal(encoded, msg.Interface()); err != nil {
+			return nil, err
+		}
+		return msg.Interface(), nil
+	}
+
+	var fields map[string]any
+	if err := v.UnwrapTo(&fields); err != nil {
+		return nil, err
+	}
+	if err := setProtoFields(msg, fields); err != nil {
+		return nil, err
+	}
+	return msg.Interface(), nil
+}
+
+// protoMessageToMap converts the fields of a message to a map keyed by proto field name.
+// Fields without presence, such as proto3 scalars, lists and maps, are always included, even when zero,
+// so that every node observes the same keys. Messages, oneofs and optional fields are only included when set.
+// Integers are converted to int64 or uint64, enums to their number, and messages to nested maps.
+func protoMessageToMap(msg protoreflect.Message) map[string]any {
+	fields := map[string]any{}
+	descriptors := msg.Descriptor().Fields()
+	for i := range descriptors.Len() {
+		fd := descriptors.Get(i)
+		if fd.HasPresence() && !msg.Has(fd) {
+			continue
+		}
+		fields[string(fd.Name())] = protoFieldToAny(fd, msg.Get(fd))
+	}
+	return fields
+}
+
+func protoFieldToAny(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		items := make([]any, list.Len())
+		for i := range items {
+			items[i] = protoSingularToAny(fd, list.Get(i))
+		}
+		return items
+	case fd.IsMap():
+		entries := map[string]any{}
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			entries[k.String()] = protoSingularToAny(fd.MapValue(), v)
+			return true
+		})
+		return entries
+	default:
+		return protoSingularToAny(fd, v)
+	}
+}
+
+func protoSingularToAny(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.EnumKind:
+		return int64(v.Enum())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.StringKind:
+		return v.String()
+	case protoreflect.BytesKind:
+		return v.Bytes()
+	default:
+		return protoMessageToMap(v.Message())
+	}
+}
+
+// setProtoFields sets the fields of msg from a map created by protoMessageToMap, after it went through consensus.
+func setProtoFields(msg protoreflect.Message, fields map[string]any) error {
+	descriptors := msg.Descriptor().Fields()
+	for name, raw := range fields {
+		fd := descriptors.ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("message %s has no field %s", msg.Descriptor().FullName(), name)
+		}
+		if raw == nil {
+			continue
+		}
+
+		if err := setProtoField(msg, fd, raw); err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setProtoField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, raw any) error {
+	switch {
+	case fd.IsList():
+		items, ok := raw.([]any)
+		if !ok {
+			return fmt.Errorf("expected a list, got %T", raw)
+		}
+		list := msg.Mutable(fd).List()
+		for _, item := range items {
+			v, err := anyToProtoSingular(fd, item, list.NewElement)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+	case fd.IsMap():
+		entries, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected a map, got %T", raw)
+		}
+		m := msg.Mutable(fd).Map()
+		for k, item := range entries {
+			key, err := parseProtoMapKey(fd.MapKey(), k)
+			if err != nil {
+				return err
+			}
+			v, err := anyToProtoSingular(fd.MapValue(), item, m.NewValue)
+			if err != nil {
+				return fmt.Errorf("key %s: %w", k, err)
+			}
+			m.Set(key, v)
+		}
+	default:
+		v, err := anyToProtoSingular(fd, raw, func() protoreflect.Value { return msg.NewField(fd) })
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, v)
+	}
+	return nil
+}
+
+// anyToProtoSingular converts a value to a single proto value of fd's kind, newMessage is used to allocate messages.
+func anyToProtoSingular(fd protoreflect.FieldDescriptor, raw any, newMessage func() protoreflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, ok := raw.(bool)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("expected a bool, got %T", raw)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.EnumKind:
+		i, err := toInt64(raw, math.MinInt32, math.MaxInt32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := toInt64(raw, math.MinInt32, math.MaxInt32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := toInt64(raw, math.MinInt64, math.MaxInt64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		u, err := toUint64(raw, math.MaxUint32)
+		return protoreflect.ValueOfUint32(uint32(u)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u, err := toUint64(raw, math.MaxUint64)
+		return protoreflect.ValueOfUint64(u), err
+	case protoreflect.FloatKind:
+		f, err := toFloat64(raw)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := toFloat64(raw)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.StringKind:
+		s, ok := raw.(string)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("expected a string, got %T", raw)
+		}
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		b, ok := raw.([]byte)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("expected bytes, got %T", raw)
+		}
+		return protoreflect.ValueOfBytes(b), nil
+	default:
+		fields, ok := raw.(map[string]any)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("expected a map for message %s, got %T", fd.Message().FullName(), raw)
+		}
+		v := newMessage()
+		return v, setProtoFields(v.Message(), fields)
+	}
+}
+
+func parseProtoMapKey(fd protoreflect.FieldDescriptor, key string) (protoreflect.MapKey, error) {
+	var v protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(key)
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(key)
+		if err != nil {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q: %w", key, err)
+		}
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(key, 10, 32)
+		if err != nil {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q: %w", key, err)
+		}
+		v = protoreflect.ValueOfInt32(int32(i))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q: %w", key, err)
+		}
+		v = protoreflect.ValueOfInt64(i)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		u, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q: %w", key, err)
+		}
+		v = protoreflect.ValueOfUint32(uint32(u))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q: %w", key, err)
+		}
+		v = protoreflect.ValueOfUint64(u)
+	default:
+		return protoreflect.MapKey{}, fmt.Errorf("unsupported map key kind %s", fd.Kind())
+	}
+	return v.MapKey(), nil
+}
+
+// toInt64 converts the numeric types produced by unwrapping values to an int64 within [minimum, maximum].
+func toInt64(raw any, minimum, maximum int64) (int64, error) {
+	var i int64
+	switch rv := reflect.ValueOf(raw); {
+	case rv.CanInt():
+		i = rv.Int()
+	case rv.CanUint():
+		if rv.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%v overflows int64", raw)
+		}
+		i = int64(rv.Uint())
+	case rv.CanFloat():
+		f := rv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not an int64", raw)
+		}
+		i = int64(f)
+	default:
+		b, ok := raw.(*big.Int)
+		if !ok || b == nil || !b.IsInt64() {
+			return 0, fmt.Errorf("expected an integer, got %T", raw)
+		}
+		i = b.Int64()
+	}
+
+	if i < minimum || i > maximum {
+		return 0, fmt.Errorf("%d is out of range [%d, %d]", i, minimum, maximum)
+	}
+	return i, nil
+}
+
+// toUint64 converts the numeric types produced by unwrapping values to a uint64 no larger than maximum.
+func toUint64(raw any, maximum uint64) (uint64, error) {
+	var u uint64
+	switch rv := reflect.ValueOf(raw); {
+	case rv.CanUint():
+		u = rv.Uint()
+	case rv.CanInt():
+		if rv.Int() < 0 {
+			return 0, fmt.Errorf("%v is negative", raw)
+		}
+		u = uint64(rv.Int())
+	case rv.CanFloat():
+		f := rv.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, fmt.Errorf("%v is not a uint64", raw)
+		}
+		u = uint64(f)
+	default:
+		b, ok := raw.(*big.Int)
+		if !ok || b == nil || !b.IsUint64() {
+			return 0, fmt.Errorf("expected an unsigned integer, got %T", raw)
+		}
+		u = b.Uint64()
+	}
+
+	if u > maximum {
+		return 0, fmt.Errorf("%d is larger than %d", u, maximum)
+	}
+	return u, nil
+}
+
+// toFloat64 converts the numeric types produced by unwrapping values to a float64.
+func toFloat64(raw any) (float64, error) {
+	switch rv := reflect.ValueOf(raw); {
+	case rv.CanFloat():
+		return rv.Float(), nil
+	case rv.CanInt():
+		return float64(rv.Int()), nil
+	case rv.CanUint():
+		return float64(rv.Uint()), nil
+	default:
+		return 0, fmt.Errorf("expected a number, got %T", raw)
+	}
+}
+
+// checkProtoFieldAggregation checks that the proto field fd can be aggregated with the given aggregation.
+func checkProtoFieldAggregation(fd protoreflect.FieldDescriptor, aggregation string, nested NestedAggregation) error {
+	singular := !fd.IsList() && !fd.IsMap()
+	switch aggregation {
+	case "ignore", "identical":
+		return nil
+	case "median":
+		if !singular || !isNumericProtoKind(fd.Kind()) {
+			return fmt.Errorf("field %s marked as median but is not a numeric type", fd.Name())
+		}
+		return nil
+	case "common_prefix", "common_suffix":
+		if !fd.IsList() {
+			return fmt.Errorf("field %s marked as %s but is not a repeated field", fd.Name(), aggregation)
+		}
+		return nil
+	case "nested":
+		if nested == nil {
+			return fmt.Errorf("nested field %s has no aggregation", fd.Name())
+		}
+		if !singular || fd.Message() == nil {
+			return fmt.Errorf("field %s marked as nested but is not a message", fd.Name())
+		}
+		if typed, ok := nested.(interface {
+			aggregatedMessage() protoreflect.MessageDescriptor
+		}); ok {
+			if md := typed.aggregatedMessage(); md == nil || md.FullName() != fd.Message().FullName() {
+				return fmt.Errorf("nested field %s is a %s, but its aggregation is not for that message", fd.Name(), fd.Message().FullName())
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown consensus aggregation: %s on field %s", aggregation, fd.Name())
+	}
+}
+
+func isNumericProtoKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind,
+		protoreflect.FloatKind, protoreflect.DoubleKind:
+		return true
+	default:
+		return false
+	}
+}
+
+func isProtoMessage(t reflect.Type) bool {
+	return t != nil && t.Implements(reflect.TypeFor[proto.Message]())
+}
@@ -0,0 +1,133 @@
+package cre
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/typepb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtoMessageToMap(t *testing.T) {
+	api := &apipb.Api{
+		Name: "api",
+		Methods: []*apipb.Method{
+			{Name: "one", RequestStreaming: true, Syntax: typepb.Syntax_SYNTAX_PROTO3},
+			{Name: "two", ResponseTypeUrl: "type.googleapis.com/two"},
+		},
+		Options:       []*typepb.Option{{Name: "option", Value: &anypb.Any{TypeUrl: "url", Value: []byte{1, 2}}}},
+		SourceContext: &sourcecontextpb.SourceContext{FileName: "file.proto"},
+		Syntax:        typepb.Syntax_SYNTAX_EDITIONS,
+	}
+
+	fields := protoMessageToMap(api.ProtoReflect())
+	assert.Equal(t, map[string]any{
+		"name":    "api",
+		"version": "",
+		"edition": "",
+		"methods": []any{
+			map[string]any{
+				"name":               "one",
+				"request_type_url":   "",
+				"request_streaming":  true,
+				"response_type_url":  "",
+				"response_streaming": false,
+				"options":            []any{},
+				"syntax":             int64(1),
+				"edition":            "",
+			},
+			map[string]any{
+				"name":               "two",
+				"request_type_url":   "",
+				"request_streaming":  false,
+				"response_type_url":  "type.googleapis.com/two",
+				"response_streaming": false,
+				"options":            []any{},
+				"syntax":             int64(0),
+				"edition":            "",
+			},
+		},
+		"options":        []any{map[string]any{"name": "option", "value": map[string]any{"type_url": "url", "value": []byte{1, 2}}}},
+		"source_context": map[string]any{"file_name": "file.proto"},
+		"mixins":         []any{},
+		"syntax":         int64(2),
+	}, fields)
+
+	actual := &apipb.Api{}
+	require.NoError(t, setProtoFields(actual.ProtoReflect(), fields))
+	assert.True(t, proto.Equal(api, actual))
+}
+
+func TestProtoMessageToMap_ZeroValues(t *testing.T) {
+	t.Run("zero scalars are included", func(t *testing.T) {
+		assert.Equal(t, map[string]any{"value": int64(0)}, protoMessageToMap(wrapperspb.Int64(0).ProtoReflect()))
+		assert.Equal(t, map[string]any{"value": false}, protoMessageToMap(wrapperspb.Bool(false).ProtoReflect()))
+	})
+
+	t.Run("unset messages are omitted", func(t *testing.T) {
+		assert.Equal(t, map[string]any{"type_url": "", "value": []byte(nil)}, protoMessageToMap((&anypb.Any{}).ProtoReflect()))
+		assert.NotContains(t, protoMessageToMap((&apipb.Api{}).ProtoReflect()), "source_context")
+	})
+
+	t.Run("zero observations keep the keys of other nodes", func(t *testing.T) {
+		descriptor := ConsensusMedianAggregation[int64]().Descriptor()
+		zero, err := wrapProtoObservation(wrapperspb.Int64(0), descriptor)
+		require.NoError(t, err)
+		nonZero, err := wrapProtoObservation(wrapperspb.Int64(7), descriptor)
+		require.NoError(t, err)
+
+		var zeroFields, nonZeroFields map[string]any
+		require.NoError(t, zero.UnwrapTo(&zeroFields))
+		require.NoError(t, nonZero.UnwrapTo(&nonZeroFields))
+		assert.Equal(t, int64(0), zeroFields["value"])
+		assert.Len(t, zeroFields, len(nonZeroFields))
+
+		actual, err := unwrapProtoObservation(&wrapperspb.Int64Value{}, zero, descriptor)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(wrapperspb.Int64(0), actual))
+	})
+}
+
+func TestSetProtoFields(t *testing.T) {
+	t.Run("maps and oneofs", func(t *testing.T) {
+		s, err := structpb.NewStruct(map[string]any{
+			"number": 1.5,
+			"list":   []any{"a", true, nil},
+			"nested": map[string]any{"b": "c"},
+		})
+		require.NoError(t, err)
+
+		actual := &structpb.Struct{}
+		require.NoError(t, setProtoFields(actual.ProtoReflect(), protoMessageToMap(s.ProtoReflect())))
+		assert.True(t, proto.Equal(s, actual))
+	})
+
+	t.Run("numbers are converted to the field's type", func(t *testing.T) {
+		u64 := &wrapperspb.UInt64Value{}
+		require.NoError(t, setProtoFields(u64.ProtoReflect(), map[string]any{"value": big.NewInt(7)}))
+		assert.Equal(t, uint64(7), u64.Value)
+
+		i32 := &wrapperspb.Int32Value{}
+		require.NoError(t, setProtoFields(i32.ProtoReflect(), map[string]any{"value": float64(-3)}))
+		assert.Equal(t, int32(-3), i32.Value)
+
+		f := &wrapperspb.FloatValue{}
+		require.NoError(t, setProtoFields(f.ProtoReflect(), map[string]any{"value": int64(2)}))
+		assert.Equal(t, float32(2), f.Value)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		assert.ErrorContains(t, setProtoFields((&wrapperspb.Int32Value{}).ProtoReflect(), map[string]any{"value": int64(1) << 40}), "field value: 1099511627776 is out of range")
+		assert.ErrorContains(t, setProtoFields((&wrapperspb.UInt32Value{}).ProtoReflect(), map[string]any{"value": int64(-1)}), "field value: -1 is negative")
+		assert.ErrorContains(t, setProtoFields((&wrapperspb.Int64Value{}).ProtoReflect(), map[string]any{"value": 1.5}), "field value: 1.5 is not an int64")
+		assert.ErrorContains(t, setProtoFields((&wrapperspb.StringValue{}).ProtoReflect(), map[string]any{"value": 1}), "field value: expected a string, got int")
+		assert.ErrorContains(t, setProtoFields((&wrapperspb.StringValue{}).ProtoReflect(), map[string]any{"missing": "a"}), "message google.protobuf.StringValue has no field missing")
+	})
+}
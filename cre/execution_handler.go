@@ -1,6 +1,8 @@
 package cre
 
 import (
+	"fmt"
+
 	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -93,21 +95,11 @@ func wrapTypedPreHook[C any, M proto.Message, T any](trigger Trigger[M, T], preH
 }
 
 func handler[R, C any, M proto.Message, T any, O any](trigger Trigger[M, T], callback func(config C, runtime R, payload T) (O, error), requirements *sdk.Requirements) ExecutionHandler[C, R] {
-	wrapped := func(config C, runtime R, payload *anypb.Any) (any, error) {
-		unwrappedTrigger := trigger.NewT()
-		if err := payload.UnmarshalTo(unwrappedTrigger); err != nil {
-			return nil, err
-		}
-		input, err := trigger.Adapt(unwrappedTrigger)
-		if err != nil {
-			return nil, err
-		}
-		return callback(config, runtime, input)
-	}
-
 	eh := &executionHandlerImpl[C, R, M, T]{
 		Trigger: trigger,
-		fn:      wrapped,
+		fn: func(config C, runtime R, payload T) (any, error) {
+			return callback(config, runtime, payload)
+		},
 	}
 
 	if requirements == nil {
@@ -119,7 +111,7 @@ func handler[R, C any, M proto.Message, T any, O any](trigger Trigger[M, T], cal
 
 type executionHandlerImpl[C, R any, M proto.Message, T any] struct {
 	Trigger[M, T]
-	fn func(config C, runtime R, trigger *anypb.Any) (any, error)
+	fn func(config C, runtime R, payload T) (any, error)
 }
 
 var _ ExecutionHandler[int, any] = (*executionHandlerImpl[int, any, proto.Message, any])(nil)
@@ -129,7 +121,32 @@ func (h *executionHandlerImpl[C, R, M, T]) TriggerCfg() *anypb.Any {
 }
 
 func (h *executionHandlerImpl[C, R, M, T]) Callback() func(config C, runtime R, payload *anypb.Any) (any, error) {
-	return h.fn
+	return func(config C, runtime R, payload *anypb.Any) (any, error) {
+		input, err := h.decode(payload)
+		if err != nil {
+			return nil, err
+		}
+		return h.invoke(config, runtime, input)
+	}
+}
+
+func (h *executionHandlerImpl[C, R, M, T]) decode(payload *anypb.Any) (any, error) {
+	unwrappedTrigger := h.NewT()
+	if err := payload.UnmarshalTo(unwrappedTrigger); err != nil {
+		return nil, err
+	}
+	return h.Adapt(unwrappedTrigger)
+}
+
+func (h *executionHandlerImpl[C, R, M, T]) invoke(config C, runtime R, payload any) (any, error) {
+	var input T
+	if payload != nil {
+		var ok bool
+		if input, ok = payload.(T); !ok {
+			return nil, fmt.Errorf("trigger payload has type %T, but the callback expects %T", payload, input)
+		}
+	}
+	return h.fn(config, runtime, input)
 }
 
 type executionHandlerWithRequirementsImpl[C, R any] struct {
@@ -144,6 +161,14 @@ func (h *executionHandlerWithRequirementsImpl[C, R]) Requirements() *sdk.Require
 	return h.requirements
 }
 
+func (h *executionHandlerWithRequirementsImpl[C, R]) unwrap() ExecutionHandler[C, R] {
+	return h.ExecutionHandler
+}
+
+func (h *executionHandlerWithRequirementsImpl[C, R]) decorate(inner ExecutionHandler[C, R]) ExecutionHandler[C, R] {
+	return &executionHandlerWithRequirementsImpl[C, R]{ExecutionHandler: inner, requirements: h.requirements}
+}
+
 type executionHandlerWithPreHookImpl[C, R any] struct {
 	ExecutionHandler[C, R]
 	preHook func(config C, payload *anypb.Any) (*sdk.Restrictions, error)
@@ -153,6 +178,14 @@ func (h *executionHandlerWithPreHookImpl[C, R]) PreHook(config C, payload *anypb
 	return h.preHook(config, payload)
 }
 
+func (h *executionHandlerWithPreHookImpl[C, R]) unwrap() ExecutionHandler[C, R] {
+	return h.ExecutionHandler
+}
+
+func (h *executionHandlerWithPreHookImpl[C, R]) decorate(inner ExecutionHandler[C, R]) ExecutionHandler[C, R] {
+	return &executionHandlerWithPreHookImpl[C, R]{ExecutionHandler: inner, preHook: h.preHook}
+}
+
 // Requirements forwards to the wrapped handler when it implements ExecutionHandlerWithRequirements,
 // otherwise returns nil. This lets a single decorator type cover both PreHook and Requirements.
 func (h *executionHandlerWithPreHookImpl[C, R]) Requirements() *sdk.Requirements {
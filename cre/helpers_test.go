@@ -4,4 +4,7 @@ import "sync"
 
 func ClearRepostSignatureCache() {
 	keyCache = &sync.Map{}
+	donCacheHits.Store(0)
+	donCacheMisses.Store(0)
+	donCacheRefreshes.Store(0)
 }
@@ -0,0 +1,105 @@
+package cre
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// HandlerFunc is the callback of an ExecutionHandler after the trigger payload has been decoded.
+// For handlers created with Handler and its variants, payload has the type the trigger adapts its output to.
+// For other ExecutionHandler implementations, payload is the raw *anypb.Any sent by the host.
+type HandlerFunc[C, R any] func(config C, runtime R, payload any) (any, error)
+
+// Middleware wraps the callback of an ExecutionHandler.
+// A Middleware can inspect or replace the config, runtime, and payload before calling next, and the result or error after.
+// A replaced payload must have the same type as the original one.
+type Middleware[C, R any] func(next HandlerFunc[C, R]) HandlerFunc[C, R]
+
+// WithMiddleware returns a handler whose callback is wrapped by the middleware.
+// The first middleware is the outermost, so it runs first and sees the final result.
+// The handler's trigger, Requirements, and PreHook are preserved; the PreHook is not wrapped.
+func WithMiddleware[C, R any](handler ExecutionHandler[C, R], middleware ...Middleware[C, R]) ExecutionHandler[C, R] {
+	if decorated, ok := handler.(handlerDecorator[C, R]); ok {
+		return decorated.decorate(WithMiddleware(decorated.unwrap(), middleware...))
+	}
+
+	inner := asPayloadCallback(handler)
+	next := HandlerFunc[C, R](inner.invoke)
+	for i := len(middleware) - 1; i >= 0; i-- {
+		next = middleware[i](next)
+	}
+
+	return &middlewareHandler[C, R]{ExecutionHandler: handler, inner: inner, next: next}
+}
+
+// Use wraps the callback of every handler in the workflow with the middleware, see WithMiddleware.
+func Use[C any](workflow Workflow[C], middleware ...Middleware[C, Runtime]) Workflow[C] {
+	wrapped := make(Workflow[C], len(workflow))
+	for i, handler := range workflow {
+		wrapped[i] = WithMiddleware(handler, middleware...)
+	}
+	return wrapped
+}
+
+// handlerDecorator is implemented by handlers that add capabilities, such as Requirements or a PreHook, to another handler.
+type handlerDecorator[C, R any] interface {
+	unwrap() ExecutionHandler[C, R]
+	decorate(inner ExecutionHandler[C, R]) ExecutionHandler[C, R]
+}
+
+// payloadCallback splits a callback into decoding the trigger payload and invoking the user's function with it.
+type payloadCallback[C, R any] interface {
+	decode(payload *anypb.Any) (any, error)
+	invoke(config C, runtime R, payload any) (any, error)
+}
+
+func asPayloadCallback[C, R any](handler ExecutionHandler[C, R]) payloadCallback[C, R] {
+	if pc, ok := handler.(payloadCallback[C, R]); ok {
+		return pc
+	}
+	return rawPayloadCallback[C, R]{handler: handler}
+}
+
+// rawPayloadCallback adapts ExecutionHandler implementations from outside this package, whose payload cannot be decoded before the callback.
+type rawPayloadCallback[C, R any] struct {
+	handler ExecutionHandler[C, R]
+}
+
+func (r rawPayloadCallback[C, R]) decode(payload *anypb.Any) (any, error) {
+	return payload, nil
+}
+
+func (r rawPayloadCallback[C, R]) invoke(config C, runtime R, payload any) (any, error) {
+	raw, ok := payload.(*anypb.Any)
+	if payload != nil && !ok {
+		return nil, fmt.Errorf("trigger payload has type %T, but the callback expects %T", payload, raw)
+	}
+	return r.handler.Callback()(config, runtime, raw)
+}
+
+type middlewareHandler[C, R any] struct {
+	ExecutionHandler[C, R]
+	inner payloadCallback[C, R]
+	next  HandlerFunc[C, R]
+}
+
+var _ payloadCallback[any, any] = (*middlewareHandler[any, any])(nil)
+
+func (h *middlewareHandler[C, R]) Callback() func(config C, runtime R, payload *anypb.Any) (any, error) {
+	return func(config C, runtime R, payload *anypb.Any) (any, error) {
+		input, err := h.decode(payload)
+		if err != nil {
+			return nil, err
+		}
+		return h.invoke(config, runtime, input)
+	}
+}
+
+func (h *middlewareHandler[C, R]) decode(payload *anypb.Any) (any, error) {
+	return h.inner.decode(payload)
+}
+
+func (h *middlewareHandler[C, R]) invoke(config C, runtime R, payload any) (any, error) {
+	return h.next(config, runtime, payload)
+}
@@ -0,0 +1,140 @@
+package cre_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/internal_testing/capabilities/basictrigger"
+)
+
+func recordingMiddleware(name string, calls *[]string) cre.Middleware[string, cre.Runtime] {
+	return func(next cre.HandlerFunc[string, cre.Runtime]) cre.HandlerFunc[string, cre.Runtime] {
+		return func(config string, runtime cre.Runtime, payload any) (any, error) {
+			*calls = append(*calls, name+" before "+config+" "+payload.(*basictrigger.Outputs).CoolOutput)
+			result, err := next(config, runtime, payload)
+			*calls = append(*calls, name+" after "+result.(string))
+			return result, err
+		}
+	}
+}
+
+func triggerPayload(t *testing.T, output string) *anypb.Any {
+	payload, err := anypb.New(&basictrigger.Outputs{CoolOutput: output})
+	require.NoError(t, err)
+	return payload
+}
+
+func echoCallback(config string, _ cre.Runtime, outputs *basictrigger.Outputs) (string, error) {
+	return config + ":" + outputs.CoolOutput, nil
+}
+
+func TestWithMiddleware(t *testing.T) {
+	t.Run("middleware runs in order around the callback", func(t *testing.T) {
+		var calls []string
+		handler := cre.WithMiddleware(
+			cre.Handler(basictrigger.Trigger(&basictrigger.Config{}), echoCallback),
+			recordingMiddleware("outer", &calls),
+			recordingMiddleware("inner", &calls),
+		)
+
+		result, err := handler.Callback()("cfg", nil, triggerPayload(t, "out"))
+		require.NoError(t, err)
+		assert.Equal(t, "cfg:out", result)
+		assert.Equal(t, []string{"outer before cfg out", "inner before cfg out", "inner after cfg:out", "outer after cfg:out"}, calls)
+	})
+
+	t.Run("middleware can short circuit and replace the payload", func(t *testing.T) {
+		validationErr := errors.New("invalid")
+		validate := func(next cre.HandlerFunc[string, cre.Runtime]) cre.HandlerFunc[string, cre.Runtime] {
+			return func(config string, runtime cre.Runtime, payload any) (any, error) {
+				outputs := payload.(*basictrigger.Outputs)
+				if outputs.CoolOutput == "" {
+					return nil, validationErr
+				}
+				return next(config, runtime, &basictrigger.Outputs{CoolOutput: outputs.CoolOutput + "!"})
+			}
+		}
+		handler := cre.WithMiddleware(cre.Handler(basictrigger.Trigger(&basictrigger.Config{}), echoCallback), validate)
+
+		_, err := handler.Callback()("cfg", nil, triggerPayload(t, ""))
+		assert.ErrorIs(t, err, validationErr)
+
+		result, err := handler.Callback()("cfg", nil, triggerPayload(t, "out"))
+		require.NoError(t, err)
+		assert.Equal(t, "cfg:out!", result)
+	})
+
+	t.Run("payload of the wrong type is rejected", func(t *testing.T) {
+		replace := func(next cre.HandlerFunc[string, cre.Runtime]) cre.HandlerFunc[string, cre.Runtime] {
+			return func(config string, runtime cre.Runtime, _ any) (any, error) {
+				return next(config, runtime, "not outputs")
+			}
+		}
+		handler := cre.WithMiddleware(cre.Handler(basictrigger.Trigger(&basictrigger.Config{}), echoCallback), replace)
+
+		_, err := handler.Callback()("cfg", nil, triggerPayload(t, "out"))
+		assert.ErrorContains(t, err, "trigger payload has type string")
+	})
+
+	t.Run("requirements and pre hook are preserved", func(t *testing.T) {
+		var calls []string
+		restrictions := &sdk.Restrictions{}
+		handler := cre.WithMiddleware(
+			cre.HandlerInTeeWithPreHook(
+				basictrigger.Trigger(&basictrigger.Config{}),
+				func(config string, _ cre.TeeRuntime, outputs *basictrigger.Outputs) (string, error) {
+					return config + ":" + outputs.CoolOutput, nil
+				},
+				cre.AnyTee{},
+				func(string, *basictrigger.Outputs) (*sdk.Restrictions, error) { return restrictions, nil },
+			),
+			recordingMiddleware("mw", &calls),
+		)
+
+		withPreHook, ok := handler.(cre.ExecutionHandlerWithPreHook[string, cre.Runtime])
+		require.True(t, ok)
+		actual, err := withPreHook.PreHook("cfg", triggerPayload(t, "out"))
+		require.NoError(t, err)
+		assert.Same(t, restrictions, actual)
+
+		withRequirements, ok := handler.(cre.ExecutionHandlerWithRequirements[string, cre.Runtime])
+		require.True(t, ok)
+		assert.NotNil(t, withRequirements.Requirements())
+		assert.Empty(t, calls)
+	})
+
+	t.Run("middleware can be added more than once", func(t *testing.T) {
+		var calls []string
+		handler := cre.WithMiddleware(cre.Handler(basictrigger.Trigger(&basictrigger.Config{}), echoCallback), recordingMiddleware("first", &calls))
+		handler = cre.WithMiddleware(handler, recordingMiddleware("second", &calls))
+
+		_, err := handler.Callback()("cfg", nil, triggerPayload(t, "out"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"second before cfg out", "first before cfg out", "first after cfg:out", "second after cfg:out"}, calls)
+	})
+}
+
+func TestUse(t *testing.T) {
+	var calls []string
+	trigger := basictrigger.Trigger(&basictrigger.Config{})
+	workflow := cre.Use(cre.Workflow[string]{
+		cre.Handler(trigger, echoCallback),
+		cre.Handler(trigger, func(config string, _ cre.Runtime, outputs *basictrigger.Outputs) (string, error) {
+			return outputs.CoolOutput + ":" + config, nil
+		}),
+	}, recordingMiddleware("mw", &calls))
+
+	require.Len(t, workflow, 2)
+	for _, handler := range workflow {
+		assert.Equal(t, trigger.CapabilityID(), handler.CapabilityID())
+		_, err := handler.Callback()("cfg", nil, triggerPayload(t, "out"))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"mw before cfg out", "mw after cfg:out", "mw before cfg out", "mw after out:cfg"}, calls)
+}
@@ -1,6 +1,8 @@
 package cre
 
 import (
+	"errors"
+	"fmt"
 	"sync"
 )
 
@@ -24,6 +26,10 @@ func PromiseFromResult[T any](result T, err error) Promise[T] {
 
 type basicPromise[T any] struct {
 	await func() (T, error)
+
+	// parents are the promises this one was derived from, e.g. via Then or ThenPromise.
+	// They allow AwaitAll to find the host calls that need settling before await is invoked.
+	parents []any
 }
 
 func (t *basicPromise[T]) Await() (T, error) {
@@ -32,10 +38,23 @@ func (t *basicPromise[T]) Await() (T, error) {
 
 func (t *basicPromise[T]) promise() {}
 
+func (t *basicPromise[T]) pendingCalls() []*capabilityPromise {
+	var calls []*capabilityPromise
+	for _, parent := range t.parents {
+		calls = append(calls, pendingCallsOf(parent)...)
+	}
+	return calls
+}
+
+// derivedPromise creates a lazy Promise, like NewBasicPromise, that remembers the promises it was derived from.
+func derivedPromise[T any](await func() (T, error), parents ...any) Promise[T] {
+	return &basicPromise[T]{await: sync.OnceValues(await), parents: parents}
+}
+
 // Then allows chaining of promises, upon success, the result of the first promise is passed to the provided function.
 // Note that the callback in `fn` will not be executed until Await is called on the returned Promise.
 func Then[I, O any](p Promise[I], fn func(I) (O, error)) Promise[O] {
-	return NewBasicPromise[O](func() (O, error) {
+	return derivedPromise(func() (O, error) {
 		underlyingResult, err := p.Await()
 		if err != nil {
 			var o O
@@ -43,18 +62,116 @@ func Then[I, O any](p Promise[I], fn func(I) (O, error)) Promise[O] {
 		}
 
 		return fn(underlyingResult)
-	})
+	}, p)
 }
 
 // ThenPromise allows chaining of promises, similar to Then, but the provided function returns a Promise.
 // This is useful when the next step in the chain is also asynchronous.
 func ThenPromise[I, O any](p Promise[I], fn func(I) Promise[O]) Promise[O] {
-	return NewBasicPromise[O](func() (O, error) {
+	return derivedPromise(func() (O, error) {
 		underlyingResult, err := p.Await()
 		if err != nil {
 			var o O
 			return o, err
 		}
 		return fn(underlyingResult).Await()
-	})
+	}, p)
+}
+
+// Recover allows handling the error of a promise, upon failure, the error is passed to the provided function.
+// The function can return a fallback value, or an error, which may be the original one, to keep the promise failed.
+// Upon success, the result is passed through unchanged.
+// Note that the callback in `fn` will not be executed until Await is called on the returned Promise.
+func Recover[T any](p Promise[T], fn func(error) (T, error)) Promise[T] {
+	return derivedPromise(func() (T, error) {
+		result, err := p.Await()
+		if err != nil {
+			return fn(err)
+		}
+		return result, nil
+	}, p)
+}
+
+// Catch allows handling the error of a promise, similar to Recover, but the provided function returns a Promise.
+// This is useful when the fallback is also asynchronous, for example, reading from a secondary chain when the primary one fails.
+// The fallback is only started once the original promise is awaited and has failed.
+// If fn returns a nil Promise, the returned Promise fails with the original error wrapped.
+func Catch[T any](p Promise[T], fn func(error) Promise[T]) Promise[T] {
+	return derivedPromise(func() (T, error) {
+		result, err := p.Await()
+		if err != nil {
+			fallback := fn(err)
+			if fallback == nil {
+				var t T
+				return t, fmt.Errorf("cre: catch fallback returned a nil promise: %w", err)
+			}
+			return fallback.Await()
+		}
+		return result, nil
+	}, p)
+}
+
+// Finally calls the provided function once the promise has settled, regardless of whether it succeeded or failed.
+// The result and error of the original promise are passed through unchanged.
+// Note that the callback in `fn` will not be executed until Await is called on the returned Promise.
+func Finally[T any](p Promise[T], fn func()) Promise[T] {
+	return derivedPromise(func() (T, error) {
+		defer fn()
+		return p.Await()
+	}, p)
+}
+
+// FirstSuccessful returns a Promise with the result of the first promise, in the order provided, that succeeds.
+// The capability calls behind all the promises are settled together, as with AwaitAll, and the results are then
+// checked in order; no promise after the first successful one is awaited.
+// If every promise fails, the returned Promise fails with all their errors joined.
+func FirstSuccessful[T any](promises ...Promise[T]) Promise[T] {
+	parents := make([]any, len(promises))
+	for i, p := range promises {
+		parents[i] = p
+	}
+
+	return derivedPromise(func() (T, error) {
+		settleAll(parents...)
+		errs := make([]error, 0, len(promises))
+		for i, p := range promises {
+			result, err := p.Await()
+			if err == nil {
+				return result, nil
+			}
+			errs = append(errs, fmt.Errorf("promise %d: %w", i, err))
+		}
+
+		var t T
+		if len(errs) == 0 {
+			return t, errors.New("FirstSuccessful requires at least one promise")
+		}
+		return t, errors.Join(errs...)
+	}, parents...)
+}
+
+// Pair holds the results of two promises combined by Zip.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip combines two promises into one that resolves to both of their results.
+// The capability calls behind both promises are settled together, as with AwaitAll2.
+// If either promise fails, the returned Promise fails with the first error, in argument order.
+func Zip[A, B any](pa Promise[A], pb Promise[B]) Promise[Pair[A, B]] {
+	return derivedPromise(func() (Pair[A, B], error) {
+		settleAll(pa, pb)
+		a, err := pa.Await()
+		if err != nil {
+			return Pair[A, B]{}, err
+		}
+
+		b, err := pb.Await()
+		if err != nil {
+			return Pair[A, B]{}, err
+		}
+
+		return Pair[A, B]{First: a, Second: b}, nil
+	}, pa, pb)
 }
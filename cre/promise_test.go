@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 )
@@ -119,3 +120,163 @@ func TestThenPromiseHandlesFnError(t *testing.T) {
 	_, err := chained.Await()
 	assert.ErrorIs(t, err, fnErr)
 }
+
+func TestRecover(t *testing.T) {
+	t.Run("recovers from error", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		p := cre.Recover(cre.PromiseFromResult(0, expectedErr), func(err error) (int, error) {
+			assert.ErrorIs(t, err, expectedErr)
+			return 7, nil
+		})
+
+		result, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, 7, result)
+	})
+
+	t.Run("passes through success", func(t *testing.T) {
+		p := cre.Recover(cre.PromiseFromResult(3, nil), func(error) (int, error) {
+			assert.Fail(t, "should not be called")
+			return 0, nil
+		})
+
+		result, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, 3, result)
+	})
+
+	t.Run("can return a new error", func(t *testing.T) {
+		wrapped := errors.New("wrapped")
+		p := cre.Recover(cre.PromiseFromResult(0, errors.New("boom")), func(err error) (int, error) {
+			return 0, wrapped
+		})
+
+		_, err := p.Await()
+		assert.ErrorIs(t, err, wrapped)
+	})
+}
+
+func TestCatch(t *testing.T) {
+	t.Run("falls back lazily", func(t *testing.T) {
+		fallbackCalls := 0
+		p := cre.Catch(cre.PromiseFromResult("", errors.New("primary failed")), func(err error) cre.Promise[string] {
+			fallbackCalls++
+			return cre.PromiseFromResult("secondary", nil)
+		})
+		assert.Equal(t, 0, fallbackCalls)
+
+		result, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, "secondary", result)
+
+		_, _ = p.Await()
+		assert.Equal(t, 1, fallbackCalls)
+	})
+
+	t.Run("passes through success", func(t *testing.T) {
+		p := cre.Catch(cre.PromiseFromResult("primary", nil), func(error) cre.Promise[string] {
+			assert.Fail(t, "should not be called")
+			return cre.PromiseFromResult("secondary", nil)
+		})
+
+		result, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, "primary", result)
+	})
+
+	t.Run("returns fallback error", func(t *testing.T) {
+		fallbackErr := errors.New("secondary failed")
+		p := cre.Catch(cre.PromiseFromResult("", errors.New("primary failed")), func(error) cre.Promise[string] {
+			return cre.PromiseFromResult("", fallbackErr)
+		})
+
+		_, err := p.Await()
+		assert.ErrorIs(t, err, fallbackErr)
+	})
+
+	t.Run("nil fallback fails with the original error", func(t *testing.T) {
+		primaryErr := errors.New("primary failed")
+		p := cre.Catch(cre.PromiseFromResult("", primaryErr), func(error) cre.Promise[string] {
+			return nil
+		})
+
+		_, err := p.Await()
+		require.ErrorIs(t, err, primaryErr)
+		assert.ErrorContains(t, err, "cre: catch fallback returned a nil promise")
+	})
+}
+
+func TestFinally(t *testing.T) {
+	expectedErr := errors.New("boom")
+	for name, tc := range map[string]struct {
+		result int
+		err    error
+	}{
+		"success": {result: 1},
+		"failure": {err: expectedErr},
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			p := cre.Finally(cre.PromiseFromResult(tc.result, tc.err), func() { calls++ })
+			assert.Equal(t, 0, calls)
+
+			result, err := p.Await()
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.err, err)
+			_, _ = p.Await()
+			assert.Equal(t, 1, calls)
+		})
+	}
+}
+
+func TestFirstSuccessful(t *testing.T) {
+	t.Run("returns first success in order", func(t *testing.T) {
+		awaited := 0
+		p := cre.FirstSuccessful(
+			cre.PromiseFromResult(0, errors.New("first failed")),
+			cre.PromiseFromResult(2, nil),
+			cre.NewBasicPromise(func() (int, error) {
+				awaited++
+				return 3, nil
+			}),
+		)
+
+		result, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, 2, result)
+		assert.Equal(t, 0, awaited, "promises after the first success should not be awaited")
+	})
+
+	t.Run("joins errors when all fail", func(t *testing.T) {
+		err1 := errors.New("first failed")
+		err2 := errors.New("second failed")
+
+		_, err := cre.FirstSuccessful(cre.PromiseFromResult(0, err1), cre.PromiseFromResult(0, err2)).Await()
+		assert.ErrorIs(t, err, err1)
+		assert.ErrorIs(t, err, err2)
+	})
+
+	t.Run("fails without promises", func(t *testing.T) {
+		_, err := cre.FirstSuccessful[int]().Await()
+		assert.Error(t, err)
+	})
+}
+
+func TestZip(t *testing.T) {
+	t.Run("combines results", func(t *testing.T) {
+		result, err := cre.Zip(cre.PromiseFromResult(1, nil), cre.PromiseFromResult("one", nil)).Await()
+		require.NoError(t, err)
+		assert.Equal(t, cre.Pair[int, string]{First: 1, Second: "one"}, result)
+	})
+
+	t.Run("returns first error", func(t *testing.T) {
+		err1 := errors.New("first failed")
+		err2 := errors.New("second failed")
+
+		_, err := cre.Zip(cre.PromiseFromResult(0, err1), cre.PromiseFromResult("", err2)).Await()
+		assert.ErrorIs(t, err, err1)
+
+		_, err = cre.Zip(cre.PromiseFromResult(0, nil), cre.PromiseFromResult("", err2)).Await()
+		assert.ErrorIs(t, err, err2)
+	})
+}
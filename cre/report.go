@@ -24,6 +24,26 @@ var (
 	ErrDuplicateSigner = errors.New("duplicate signer")
 	// ErrRawReportTooShort is returned when the raw report is shorter than the 109-byte metadata header.
 	ErrRawReportTooShort = errors.New("raw report too short to contain metadata header")
+	// ErrUnexpectedDON is returned when a report verified offline was not created by the expected DON.
+	ErrUnexpectedDON = errors.New("report is from an unexpected DON")
+	// ErrReportTooOld is returned when the report's timestamp is older than ReportParseConfig.MaxAge.
+	ErrReportTooOld = errors.New("report is too old")
+	// ErrWorkflowOwnerNotAccepted is returned when the report's workflow owner is not in ReportParseConfig.AcceptedWorkflowOwners.
+	ErrWorkflowOwnerNotAccepted = errors.New("workflow owner is not accepted")
+	// ErrWorkflowIDNotAccepted is returned when the report's workflow ID is not in ReportParseConfig.AcceptedWorkflowIDs.
+	ErrWorkflowIDNotAccepted = errors.New("workflow ID is not accepted")
+	// ErrWorkflowNameNotAccepted is returned when the report's workflow name is not in ReportParseConfig.AcceptedWorkflowNames.
+	ErrWorkflowNameNotAccepted = errors.New("workflow name is not accepted")
+	// ErrReportIDNotAccepted is returned when the report's ID is not in ReportParseConfig.AcceptedReportIDs.
+	ErrReportIDNotAccepted = errors.New("report ID is not accepted")
+	// ErrDONConfigVersionTooOld is returned when the report's DON config version is below ReportParseConfig.MinDONConfigVersion.
+	ErrDONConfigVersionTooOld = errors.New("DON config version is too old")
+	// ErrUnknownDONConfigVersion is returned when the report's DON config version is newer than the configCount of the DON in the capabilities registry.
+	ErrUnknownDONConfigVersion = errors.New("DON config version is not in the capabilities registry")
+	// ErrReplayedReport is returned when ReportParseConfig.ReplayGuard has already seen the report's execution ID and sequence number.
+	ErrReplayedReport = errors.New("report was already processed")
+	// ErrInvalidReportRequest is returned when a report request would produce a report that forwarders reject.
+	ErrInvalidReportRequest = errors.New("invalid report request")
 )
 
 // Report contains a signed report from the CRE workflow DON.
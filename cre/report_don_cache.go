@@ -0,0 +1,90 @@
+package cre
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// keyCache holds the DON info read from the capabilities registry, keyed by donCacheKey.
+var keyCache = &sync.Map{}
+
+var donCacheHits, donCacheMisses, donCacheRefreshes atomic.Uint64
+
+// donCacheKey identifies a DON in a CRE environment.
+// The entry holds the DON's configuration as returned by the registry, including its config count,
+// so a report claiming a newer DON config version than the cached one is never verified against older signers.
+type donCacheKey struct {
+	chainSelector   uint64
+	registryAddress string
+	donID           uint32
+}
+
+type donCacheEntry struct {
+	info      donInfo
+	fetchedAt time.Time
+}
+
+func newDONCacheKey(env Environment, donID uint32) donCacheKey {
+	return donCacheKey{
+		chainSelector:   env.ChainSelector,
+		registryAddress: strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(env.RegistryAddress, "0x"), "0X")),
+		donID:           donID,
+	}
+}
+
+// DONCacheStats counts lookups of the DON info used to verify report signatures.
+type DONCacheStats struct {
+	// Hits is the number of lookups answered from the cache.
+	Hits uint64
+	// Misses is the number of lookups that read a DON from the chain because it was not cached,
+	// or because the report claims a newer DON config version than the cached one.
+	Misses uint64
+	// Refreshes is the number of lookups that read a DON configuration from the chain again, because its cache entry expired.
+	Refreshes uint64
+}
+
+// DONCacheStatistics returns the number of DON cache hits, misses and refreshes since the module started.
+func DONCacheStatistics() DONCacheStats {
+	return DONCacheStats{
+		Hits:      donCacheHits.Load(),
+		Misses:    donCacheMisses.Load(),
+		Refreshes: donCacheRefreshes.Load(),
+	}
+}
+
+// InvalidateDONCache removes the cached configuration of the DON in the environment.
+// The next report from the DON reads its signers from the chain again.
+func InvalidateDONCache(env Environment, donID uint32) {
+	keyCache.Delete(newDONCacheKey(env, donID))
+}
+
+// loadDONInfo returns the cached DON info for the key, if there is an entry that is younger than ttl
+// and whose config count is at least configVersion.
+// A ttl of zero means entries do not expire.
+func loadDONInfo(key donCacheKey, configVersion uint32, now time.Time, ttl time.Duration) (donInfo, bool) {
+	cached, ok := keyCache.Load(key)
+	if !ok {
+		donCacheMisses.Add(1)
+		return donInfo{}, false
+	}
+
+	entry := cached.(donCacheEntry)
+	if configVersion > entry.info.configCount {
+		donCacheMisses.Add(1)
+		return donInfo{}, false
+	}
+
+	if ttl > 0 && now.Sub(entry.fetchedAt) >= ttl {
+		donCacheRefreshes.Add(1)
+		return donInfo{}, false
+	}
+
+	donCacheHits.Add(1)
+	return entry.info, true
+}
+
+func storeDONInfo(key donCacheKey, now time.Time, info donInfo) {
+	keyCache.Store(key, donCacheEntry{info: info, fetchedAt: now})
+}
@@ -0,0 +1,103 @@
+package cre_test
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+)
+
+func TestDONCache(t *testing.T) {
+	// repeatedReplay allows the DON to be read from the chain twice.
+	repeatedReplay := &workflowReplay{
+		Calls:         append(append([]callReplay{}, productionEnvironmentReplay.Calls...), productionEnvironmentReplay.Calls...),
+		ChainSelector: productionEnvironmentReplay.ChainSelector,
+	}
+
+	t.Run("cached reports are hits", func(t *testing.T) {
+		setupDonSettingRead(t, repeatedReplay)
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+
+		_, err := cre.ParseReport(runtime, rawReport, reportSigs, reportContext)
+		require.NoError(t, err)
+		_, err = cre.ParseReport(runtime, rawReport, reportSigs, reportContext)
+		require.NoError(t, err)
+
+		assert.Equal(t, cre.DONCacheStats{Hits: 1, Misses: 1}, cre.DONCacheStatistics())
+	})
+
+	t.Run("newer DON config version is read from the chain", func(t *testing.T) {
+		mock := setupDonSettingRead(t, repeatedReplay)
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+
+		_, err := cre.ParseReport(runtime, rawReport, reportSigs, reportContext)
+		require.NoError(t, err)
+
+		// The registry in the replay has configCount 2, so version 3 is not known to it.
+		newConfigReport := make([]byte, len(rawReport))
+		copy(newConfigReport, rawReport)
+		binary.BigEndian.PutUint32(newConfigReport[41:45], 3)
+
+		_, err = cre.ParseReport(runtime, newConfigReport, reportSigs, reportContext)
+		require.ErrorIs(t, err, cre.ErrUnknownDONConfigVersion)
+
+		assert.Equal(t, 3, mock.actualCalls)
+		assert.Equal(t, cre.DONCacheStats{Misses: 2}, cre.DONCacheStatistics())
+
+		// The unknown version was not cached, the registry's configuration still is.
+		_, err = cre.ParseReport(runtime, rawReport, reportSigs, reportContext)
+		require.NoError(t, err)
+		assert.Equal(t, 3, mock.actualCalls)
+	})
+
+	t.Run("expired entries are refreshed", func(t *testing.T) {
+		mock := setupDonSettingRead(t, repeatedReplay)
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		now := time.Unix(1774809002, 0)
+		runtime.SetTimeProvider(func() time.Time { return now })
+		config := cre.ReportParseConfig{
+			AcceptedEnvironments: []cre.Environment{cre.ProductionEnvironment()},
+			DONCacheTTL:          time.Minute,
+		}
+
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+		require.NoError(t, err)
+
+		now = now.Add(59 * time.Second)
+		_, err = cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+		require.NoError(t, err)
+		assert.Equal(t, 2, mock.actualCalls)
+
+		now = now.Add(time.Second)
+		_, err = cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, mock.actualCalls)
+		assert.Equal(t, cre.DONCacheStats{Hits: 1, Misses: 1, Refreshes: 1}, cre.DONCacheStatistics())
+	})
+
+	t.Run("invalidated DONs are read from the chain", func(t *testing.T) {
+		mock := setupDonSettingRead(t, repeatedReplay)
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+
+		_, err := cre.ParseReport(runtime, rawReport, reportSigs, reportContext)
+		require.NoError(t, err)
+
+		cre.InvalidateDONCache(cre.ProductionEnvironment(), reportDONID+1)
+		_, err = cre.ParseReport(runtime, rawReport, reportSigs, reportContext)
+		require.NoError(t, err)
+		assert.Equal(t, 2, mock.actualCalls)
+
+		cre.InvalidateDONCache(cre.ProductionEnvironment(), reportDONID)
+		_, err = cre.ParseReport(runtime, rawReport, reportSigs, reportContext)
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, mock.actualCalls)
+		assert.Equal(t, cre.DONCacheStats{Hits: 1, Misses: 2}, cre.DONCacheStatistics())
+	})
+}
@@ -1,10 +1,6 @@
 package cre
 
-import (
-	"encoding/binary"
-	"encoding/hex"
-	"fmt"
-)
+import "encoding/hex"
 
 // reportHeader holds the decoded contents of the 109-byte metadata header prepended to every CRE
 // report by the consensus plugin, plus the body that follows it.
@@ -35,25 +31,21 @@ type reportHeader struct {
 }
 
 func parseRawReport(raw []byte) (*reportHeader, error) {
-	if raw == nil {
-		return nil, ErrNilReport
-	}
-
-	if len(raw) < ReportMetadataHeaderLength {
-		return nil, fmt.Errorf("%w: need %d bytes, got %d",
-			ErrRawReportTooShort, ReportMetadataHeaderLength, len(raw))
+	header, err := ParseReportHeader(raw)
+	if err != nil {
+		return nil, err
 	}
 
 	return &reportHeader{
-		version:          uint32(raw[0]),
-		executionID:      hex.EncodeToString(raw[1:33]),
-		timestamp:        binary.BigEndian.Uint32(raw[33:37]),
-		donID:            binary.BigEndian.Uint32(raw[37:41]),
-		donConfigVersion: binary.BigEndian.Uint32(raw[41:45]),
-		workflowID:       hex.EncodeToString(raw[45:77]),
-		workflowName:     string(raw[77:87]),
-		workflowOwner:    hex.EncodeToString(raw[87:107]),
-		reportID:         hex.EncodeToString(raw[107:109]),
+		version:          uint32(header.Version),
+		executionID:      hex.EncodeToString(header.ExecutionID[:]),
+		timestamp:        uint32(header.Timestamp.Unix()),
+		donID:            header.DONID,
+		donConfigVersion: header.DONConfigVersion,
+		workflowID:       hex.EncodeToString(header.WorkflowID[:]),
+		workflowName:     string(header.WorkflowName[:]),
+		workflowOwner:    hex.EncodeToString(header.WorkflowOwner[:]),
+		reportID:         hex.EncodeToString(header.ReportID[:]),
 		body:             raw[ReportMetadataHeaderLength:],
 	}, nil
 }
@@ -106,6 +98,20 @@ func (r *Report) RawReport() []byte {
 	return r.report.RawReport
 }
 
+// Signatures returns the signatures on the report.
+// After the report is verified, only the f+1 signatures that were accepted remain.
+func (r *Report) Signatures() [][]byte {
+	if r.report == nil {
+		return nil
+	}
+
+	sigs := make([][]byte, len(r.report.Sigs))
+	for i, sig := range r.report.Sigs {
+		sigs[i] = sig.GetSignature()
+	}
+	return sigs
+}
+
 // ── Metadata header fields (parsed from RawReport) ────────────────────────
 
 // Version returns the single-byte version field from the report metadata header.
@@ -138,7 +144,8 @@ func (r *Report) WorkflowID() string {
 	return r.cachedHeader.workflowID
 }
 
-// WorkflowName returns the 10-byte workflow name as a hex string.
+// WorkflowName returns the 10-byte workflow name as it is stored on chain.
+// It is not the workflow's name, but the first 10 characters of its hex encoded SHA-256 hash, see WorkflowNameHash.
 func (r *Report) WorkflowName() string {
 	return r.cachedHeader.workflowName
 }
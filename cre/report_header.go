@@ -0,0 +1,89 @@
+package cre
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ReportHeader is the metadata header the consensus plugin prepends to every CRE report, see ReportMetadataHeaderLength.
+// It can be built to create raw reports, for example in tests or off-chain tools, with Encode.
+type ReportHeader struct {
+	Version          uint8
+	ExecutionID      [32]byte
+	Timestamp        time.Time
+	DONID            uint32
+	DONConfigVersion uint32
+	WorkflowID       [32]byte
+	// WorkflowName is the workflow name as it is stored on chain, see WorkflowNameHash.
+	WorkflowName  [10]byte
+	WorkflowOwner common.Address
+	ReportID      [2]byte
+}
+
+// WorkflowNameHash returns a workflow's name as it is stored on chain and in report headers:
+// the first 10 characters of the hex encoded SHA-256 hash of the name.
+func WorkflowNameHash(name string) [10]byte {
+	hash := sha256.Sum256([]byte(name))
+	var truncated [10]byte
+	copy(truncated[:], hex.EncodeToString(hash[:]))
+	return truncated
+}
+
+// ParseReportHeader decodes the metadata header at the start of a raw report.
+func ParseReportHeader(rawReport []byte) (ReportHeader, error) {
+	if rawReport == nil {
+		return ReportHeader{}, ErrNilReport
+	}
+
+	if len(rawReport) < ReportMetadataHeaderLength {
+		return ReportHeader{}, fmt.Errorf("%w: need %d bytes, got %d",
+			ErrRawReportTooShort, ReportMetadataHeaderLength, len(rawReport))
+	}
+
+	header := ReportHeader{
+		Version:          rawReport[0],
+		Timestamp:        time.Unix(int64(binary.BigEndian.Uint32(rawReport[33:37])), 0).UTC(),
+		DONID:            binary.BigEndian.Uint32(rawReport[37:41]),
+		DONConfigVersion: binary.BigEndian.Uint32(rawReport[41:45]),
+		WorkflowOwner:    common.BytesToAddress(rawReport[87:107]),
+	}
+	copy(header.ExecutionID[:], rawReport[1:33])
+	copy(header.WorkflowID[:], rawReport[45:77])
+	copy(header.WorkflowName[:], rawReport[77:87])
+	copy(header.ReportID[:], rawReport[107:109])
+	return header, nil
+}
+
+// Encode encodes the header in the layout used by the consensus plugin.
+// The body of the report follows the header in a raw report.
+// The timestamp is encoded in whole seconds, and must be between 1970 and 2106.
+func (h ReportHeader) Encode() ([]byte, error) {
+	timestamp := h.Timestamp.Unix()
+	if timestamp < 0 || timestamp > math.MaxUint32 {
+		return nil, fmt.Errorf("timestamp %s cannot be encoded in a report header", h.Timestamp)
+	}
+
+	raw := make([]byte, 0, ReportMetadataHeaderLength)
+	raw = append(raw, h.Version)
+	raw = append(raw, h.ExecutionID[:]...)
+	raw = binary.BigEndian.AppendUint32(raw, uint32(timestamp))
+	raw = binary.BigEndian.AppendUint32(raw, h.DONID)
+	raw = binary.BigEndian.AppendUint32(raw, h.DONConfigVersion)
+	raw = append(raw, h.WorkflowID[:]...)
+	raw = append(raw, h.WorkflowName[:]...)
+	raw = append(raw, h.WorkflowOwner[:]...)
+	raw = append(raw, h.ReportID[:]...)
+	return raw, nil
+}
+
+// Header returns the report's metadata header with typed fields.
+// It fails like ParseReportHeader if the raw report is too short to hold a header.
+func (r *Report) Header() (ReportHeader, error) {
+	return ParseReportHeader(r.RawReport())
+}
@@ -0,0 +1,95 @@
+package cre_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+func TestParseReportHeader(t *testing.T) {
+	header, err := cre.ParseReportHeader(rawReport)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint8(reportVersion), header.Version)
+	assert.Equal(t, reportExecID, hex.EncodeToString(header.ExecutionID[:]))
+	assert.Equal(t, time.Unix(int64(reportTimestampUnix), 0).UTC(), header.Timestamp)
+	assert.Equal(t, reportDONID, header.DONID)
+	assert.Equal(t, reportDONCfgVer, header.DONConfigVersion)
+	assert.Equal(t, reportWfID, hex.EncodeToString(header.WorkflowID[:]))
+	assert.Equal(t, reportWfName, string(header.WorkflowName[:]))
+	assert.Equal(t, common.HexToAddress(reportWfOwner), header.WorkflowOwner)
+	assert.Equal(t, reportReportID, hex.EncodeToString(header.ReportID[:]))
+
+	_, err = cre.ParseReportHeader(rawReport[:cre.ReportMetadataHeaderLength-1])
+	assert.ErrorIs(t, err, cre.ErrRawReportTooShort)
+}
+
+func TestReportHeader_Encode(t *testing.T) {
+	t.Run("round trips the consensus plugin layout", func(t *testing.T) {
+		header, err := cre.ParseReportHeader(rawReport)
+		require.NoError(t, err)
+
+		encoded, err := header.Encode()
+		require.NoError(t, err)
+		assert.Equal(t, rawReport[:cre.ReportMetadataHeaderLength], encoded)
+	})
+
+	t.Run("builds reports", func(t *testing.T) {
+		header := cre.ReportHeader{
+			Version:       1,
+			Timestamp:     time.Unix(1774809002, 0),
+			DONID:         7,
+			WorkflowName:  cre.WorkflowNameHash("my-workflow"),
+			WorkflowOwner: common.HexToAddress("0x" + reportWfOwner),
+			ReportID:      [2]byte{0, 1},
+		}
+		encoded, err := header.Encode()
+		require.NoError(t, err)
+		require.Len(t, encoded, cre.ReportMetadataHeaderLength)
+
+		report, err := cre.UnmarshalReport([]byte(`{"rawReport": "` + base64.StdEncoding.EncodeToString(append(encoded, "body"...)) + `"}`))
+		require.NoError(t, err)
+		decoded, err := report.Header()
+		require.NoError(t, err)
+		assert.Equal(t, header.WorkflowName, decoded.WorkflowName)
+		assert.Equal(t, uint32(7), report.DONID())
+		assert.Equal(t, reportWfOwner, report.WorkflowOwner())
+		assert.Equal(t, "body", string(report.Body()))
+	})
+
+	t.Run("timestamp out of range", func(t *testing.T) {
+		_, err := cre.ReportHeader{Timestamp: time.Unix(-1, 0)}.Encode()
+		assert.ErrorContains(t, err, "cannot be encoded in a report header")
+
+		_, err = cre.ReportHeader{Timestamp: time.Unix(1<<32, 0)}.Encode()
+		assert.ErrorContains(t, err, "cannot be encoded in a report header")
+	})
+}
+
+func TestReport_Header(t *testing.T) {
+	don, sigs := newStaticDON(t, reportDONID, 1, 3)
+	report, err := cre.VerifyReportOffline(rawReport, sigs, reportContext, don)
+	require.NoError(t, err)
+
+	header, err := report.Header()
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(reportWfOwner), header.WorkflowOwner)
+	assert.Equal(t, int64(reportTimestampUnix), header.Timestamp.Unix())
+
+	t.Run("empty report", func(t *testing.T) {
+		_, err := (&cre.Report{}).Header()
+		require.ErrorIs(t, err, cre.ErrNilReport)
+	})
+}
+
+func TestWorkflowNameHash(t *testing.T) {
+	name := cre.WorkflowNameHash("my-workflow")
+	assert.Equal(t, "889158b6ac", string(name[:]))
+}
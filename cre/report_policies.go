@@ -0,0 +1,124 @@
+package cre
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReplayGuard remembers which reports were already accepted, see ReportParseConfig.ReplayGuard.
+type ReplayGuard interface {
+	// Seen reports whether the execution ID and sequence number were seen before, and records them if not.
+	Seen(executionID string, seqNr uint64) bool
+}
+
+type replayKey struct {
+	executionID string
+	seqNr       uint64
+}
+
+type inMemoryReplayGuard struct {
+	lock sync.Mutex
+	seen map[replayKey]struct{}
+}
+
+// NewReplayGuard returns a ReplayGuard that remembers reports in memory, for as long as the module is loaded.
+func NewReplayGuard() ReplayGuard {
+	return &inMemoryReplayGuard{seen: map[replayKey]struct{}{}}
+}
+
+func (g *inMemoryReplayGuard) Seen(executionID string, seqNr uint64) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	key := replayKey{executionID: executionID, seqNr: seqNr}
+	if _, ok := g.seen[key]; ok {
+		return true
+	}
+	g.seen[key] = struct{}{}
+	return false
+}
+
+// checkPolicies checks the report's header against the age, origin and DON config version policies in the config.
+func (r *Report) checkPolicies(runtime Runtime, config ReportParseConfig) error {
+	header, err := r.parseHeader()
+	if err != nil {
+		return err
+	}
+
+	if config.MaxAge > 0 {
+		age := runtime.Now().Sub(time.Unix(int64(header.timestamp), 0))
+		if age > config.MaxAge {
+			return fmt.Errorf("%w: report is %s old, the maximum is %s", ErrReportTooOld, age, config.MaxAge)
+		}
+	}
+
+	if !acceptedHex(config.AcceptedWorkflowOwners, header.workflowOwner) {
+		return fmt.Errorf("%w: %s", ErrWorkflowOwnerNotAccepted, header.workflowOwner)
+	}
+
+	if !acceptedHex(config.AcceptedWorkflowIDs, header.workflowID) {
+		return fmt.Errorf("%w: %s", ErrWorkflowIDNotAccepted, header.workflowID)
+	}
+
+	if !acceptedWorkflowName(config.AcceptedWorkflowNames, header.workflowName) {
+		return fmt.Errorf("%w: %q", ErrWorkflowNameNotAccepted, header.workflowName)
+	}
+
+	if !acceptedHex(config.AcceptedReportIDs, header.reportID) {
+		return fmt.Errorf("%w: %s", ErrReportIDNotAccepted, header.reportID)
+	}
+
+	if header.donConfigVersion < config.MinDONConfigVersion {
+		return fmt.Errorf("%w: got %d, need at least %d", ErrDONConfigVersionTooOld, header.donConfigVersion, config.MinDONConfigVersion)
+	}
+
+	return nil
+}
+
+func (r *Report) checkReplay(guard ReplayGuard) error {
+	if guard == nil {
+		return nil
+	}
+
+	header, err := r.parseHeader()
+	if err != nil {
+		return err
+	}
+
+	if guard.Seen(header.executionID, r.SeqNr()) {
+		return fmt.Errorf("%w: execution ID %s, sequence number %d", ErrReplayedReport, header.executionID, r.SeqNr())
+	}
+	return nil
+}
+
+// acceptedHex reports whether value is in accepted, ignoring case and 0x prefixes. An empty list accepts any value.
+func acceptedHex(accepted []string, value string) bool {
+	if len(accepted) == 0 {
+		return true
+	}
+
+	for _, a := range accepted {
+		if strings.EqualFold(strings.TrimPrefix(strings.TrimPrefix(a, "0x"), "0X"), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptedWorkflowName reports whether the on-chain workflow name is the WorkflowNameHash of a name in accepted.
+// An empty list accepts any name.
+func acceptedWorkflowName(accepted []string, workflowName string) bool {
+	if len(accepted) == 0 {
+		return true
+	}
+
+	for _, name := range accepted {
+		hash := WorkflowNameHash(name)
+		if string(hash[:]) == workflowName {
+			return true
+		}
+	}
+	return false
+}
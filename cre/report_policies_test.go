@@ -0,0 +1,121 @@
+package cre_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+)
+
+func TestReportParseConfig_Policies(t *testing.T) {
+	reportTime := time.Unix(int64(reportTimestampUnix), 0)
+
+	tests := []struct {
+		name   string
+		config cre.ReportParseConfig
+		err    error
+	}{
+		{name: "no policies"},
+		{name: "fresh report", config: cre.ReportParseConfig{MaxAge: time.Minute}},
+		{name: "old report", config: cre.ReportParseConfig{MaxAge: time.Second}, err: cre.ErrReportTooOld},
+		{name: "accepted owner", config: cre.ReportParseConfig{AcceptedWorkflowOwners: []string{"0x" + strings.ToUpper(reportWfOwner)}}},
+		{name: "unknown owner", config: cre.ReportParseConfig{AcceptedWorkflowOwners: []string{reportWfID}}, err: cre.ErrWorkflowOwnerNotAccepted},
+		{name: "accepted workflow ID", config: cre.ReportParseConfig{AcceptedWorkflowIDs: []string{reportReportID, reportWfID}}},
+		{name: "unknown workflow ID", config: cre.ReportParseConfig{AcceptedWorkflowIDs: []string{reportWfOwner}}, err: cre.ErrWorkflowIDNotAccepted},
+		{name: "unknown workflow name", config: cre.ReportParseConfig{AcceptedWorkflowNames: []string{"other"}}, err: cre.ErrWorkflowNameNotAccepted},
+		{name: "on-chain workflow name is not a name", config: cre.ReportParseConfig{AcceptedWorkflowNames: []string{reportWfName}}, err: cre.ErrWorkflowNameNotAccepted},
+		{name: "accepted report ID", config: cre.ReportParseConfig{AcceptedReportIDs: []string{"0x" + reportReportID}}},
+		{name: "unknown report ID", config: cre.ReportParseConfig{AcceptedReportIDs: []string{"0002"}}, err: cre.ErrReportIDNotAccepted},
+		{name: "current DON config version", config: cre.ReportParseConfig{MinDONConfigVersion: reportDONCfgVer}},
+		{name: "old DON config version", config: cre.ReportParseConfig{MinDONConfigVersion: reportDONCfgVer + 1}, err: cre.ErrDONConfigVersionTooOld},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime := testutils.NewRuntime(t, testutils.Secrets{})
+			runtime.SetTimeProvider(func() time.Time { return reportTime.Add(10 * time.Second) })
+
+			tt.config.SkipSignatureVerification = true
+			report, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, tt.config)
+
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, report)
+		})
+	}
+
+	t.Run("workflow names are hashed", func(t *testing.T) {
+		header, err := cre.ReportHeader{Version: 1, Timestamp: reportTime, WorkflowName: cre.WorkflowNameHash("my-workflow")}.Encode()
+		require.NoError(t, err)
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+
+		config := cre.ReportParseConfig{SkipSignatureVerification: true, AcceptedWorkflowNames: []string{"other", "my-workflow"}}
+		_, err = cre.ParseReportWithConfig(runtime, header, nil, reportContext, config)
+		require.NoError(t, err)
+
+		config.AcceptedWorkflowNames = []string{"my-workflow-2"}
+		_, err = cre.ParseReportWithConfig(runtime, header, nil, reportContext, config)
+		require.ErrorIs(t, err, cre.ErrWorkflowNameNotAccepted)
+	})
+
+	t.Run("policies are checked before reading from the chain", func(t *testing.T) {
+		mock := setupDonSettingRead(t, productionEnvironmentReplay)
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+
+		config := cre.ReportParseConfig{
+			AcceptedEnvironments:  []cre.Environment{cre.ProductionEnvironment()},
+			AcceptedWorkflowNames: []string{"other"},
+		}
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+
+		require.ErrorIs(t, err, cre.ErrWorkflowNameNotAccepted)
+		assert.Equal(t, 0, mock.actualCalls)
+	})
+}
+
+func TestReplayGuard(t *testing.T) {
+	t.Run("rejects replayed reports", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		config := cre.ReportParseConfig{SkipSignatureVerification: true, ReplayGuard: cre.NewReplayGuard()}
+
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+		require.NoError(t, err)
+
+		_, err = cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+		require.ErrorIs(t, err, cre.ErrReplayedReport)
+	})
+
+	t.Run("same execution with another sequence number is accepted", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		config := cre.ReportParseConfig{SkipSignatureVerification: true, ReplayGuard: cre.NewReplayGuard()}
+
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+		require.NoError(t, err)
+
+		nextContext := make([]byte, len(reportContext))
+		copy(nextContext, reportContext)
+		nextContext[39]++
+		_, err = cre.ParseReportWithConfig(runtime, rawReport, reportSigs, nextContext, config)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejected reports are not recorded", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		guard := cre.NewReplayGuard()
+
+		config := cre.ReportParseConfig{SkipSignatureVerification: true, ReplayGuard: guard, MinDONConfigVersion: reportDONCfgVer + 1}
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+		require.ErrorIs(t, err, cre.ErrDONConfigVersionTooOld)
+
+		assert.False(t, guard.Seen(reportExecID, reportSeqNr))
+		assert.True(t, guard.Seen(reportExecID, reportSeqNr))
+	})
+}
@@ -0,0 +1,233 @@
+package cre
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/smartcontractkit/cre-sdk-go/internal/capreg"
+)
+
+// RegistryABIV2 is the version of the capabilities registry ABI deployed in the CRE's production environment.
+// It is used for environments that do not set Environment.RegistryABIVersion.
+const RegistryABIV2 = "v2"
+
+const (
+	getDONMethod           = "getDON"
+	getNodesByP2PIdsMethod = "getNodesByP2PIds"
+)
+
+var registryABIsLock sync.RWMutex
+var registryABIs = map[string]abi.ABI{RegistryABIV2: mustParseRegistryABI(capreg.V2ABI)}
+
+// BlockConfidence selects the block that the capabilities registry is read at.
+type BlockConfidence string
+
+const (
+	// BlockConfidenceFinalized reads at the latest finalized block. It is used for environments that do not set Environment.BlockConfidence.
+	BlockConfidenceFinalized BlockConfidence = "finalized"
+	// BlockConfidenceSafe reads at the latest safe block.
+	BlockConfidenceSafe BlockConfidence = "safe"
+	// BlockConfidenceLatest reads at the latest block.
+	BlockConfidenceLatest BlockConfidence = "latest"
+)
+
+// blockNumber returns the special block number used by the EVM capability for the confidence level.
+func (c BlockConfidence) blockNumber() (int64, error) {
+	switch c {
+	case "", BlockConfidenceFinalized:
+		return -3, nil
+	case BlockConfidenceSafe:
+		return -4, nil
+	case BlockConfidenceLatest:
+		return -2, nil
+	default:
+		return 0, fmt.Errorf("unknown block confidence %q", c)
+	}
+}
+
+// RegisterRegistryABI makes a capabilities registry ABI available to environments that set Environment.RegistryABIVersion to version.
+// The ABI must have a getDON(uint32) function returning a tuple with configCount, f and nodeP2PIds, and a getNodesByP2PIds(bytes32[])
+// function returning tuples with nodeOperatorId and signer.
+func RegisterRegistryABI(version string, abiJSON string) error {
+	parsed, err := parseRegistryABI(abiJSON)
+	if err != nil {
+		return fmt.Errorf("invalid registry ABI %s: %w", version, err)
+	}
+
+	registryABIsLock.Lock()
+	defer registryABIsLock.Unlock()
+	registryABIs[version] = parsed
+	return nil
+}
+
+func registryABI(env Environment) (abi.ABI, error) {
+	version := env.RegistryABIVersion
+	if version == "" {
+		version = RegistryABIV2
+	}
+
+	registryABIsLock.RLock()
+	defer registryABIsLock.RUnlock()
+	parsed, ok := registryABIs[version]
+	if !ok {
+		return abi.ABI{}, fmt.Errorf("unknown registry ABI version %q", version)
+	}
+	return parsed, nil
+}
+
+func mustParseRegistryABI(abiJSON string) abi.ABI {
+	parsed, err := parseRegistryABI(abiJSON)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func parseRegistryABI(abiJSON string) (abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return abi.ABI{}, err
+	}
+
+	if err = checkRegistryMethod(parsed, getDONMethod, abi.TupleTy, "configCount", "f", "nodeP2PIds"); err != nil {
+		return abi.ABI{}, err
+	}
+	if err = checkRegistryMethod(parsed, getNodesByP2PIdsMethod, abi.SliceTy, "nodeOperatorId", "signer"); err != nil {
+		return abi.ABI{}, err
+	}
+	return parsed, nil
+}
+
+func checkRegistryMethod(parsed abi.ABI, name string, kind byte, fields ...string) error {
+	method, ok := parsed.Methods[name]
+	if !ok {
+		return fmt.Errorf("missing method %s", name)
+	}
+	if len(method.Outputs) != 1 || method.Outputs[0].Type.T != kind {
+		return fmt.Errorf("method %s has unexpected outputs", name)
+	}
+
+	tuple := method.Outputs[0].Type
+	if kind == abi.SliceTy {
+		tuple = *tuple.Elem
+	}
+	if tuple.T != abi.TupleTy {
+		return fmt.Errorf("method %s does not return tuples", name)
+	}
+	for _, field := range fields {
+		if !slices.Contains(tuple.TupleRawNames, field) {
+			return fmt.Errorf("method %s does not return %s", name, field)
+		}
+	}
+	return nil
+}
+
+// registryDON is the part of the getDON response used to verify reports.
+type registryDON struct {
+	configCount uint32
+	f           int
+	nodeP2PIds  [][32]byte
+}
+
+// decodeDON decodes the response of getDON into its config count, f and the P2P IDs of the DON's nodes.
+func decodeDON(registry abi.ABI, data []byte) (registryDON, error) {
+	out, err := unpackRegistryResponse(registry, getDONMethod, data)
+	if err != nil {
+		return registryDON{}, err
+	}
+
+	don := reflect.ValueOf(out[0])
+	configCount, err := tupleField(don, "configCount")
+	if err != nil {
+		return registryDON{}, fmt.Errorf("%s response: %w", getDONMethod, err)
+	}
+	configCountValue, err := toUint64(configCount.Interface(), math.MaxUint32)
+	if err != nil {
+		return registryDON{}, fmt.Errorf("%s response: field configCount: %w", getDONMethod, err)
+	}
+
+	f, err := tupleField(don, "f")
+	if err != nil {
+		return registryDON{}, fmt.Errorf("%s response: %w", getDONMethod, err)
+	}
+	fValue, err := toInt64(f.Interface(), 0, math.MaxInt32)
+	if err != nil {
+		return registryDON{}, fmt.Errorf("%s response: field f: %w", getDONMethod, err)
+	}
+
+	ids, err := tupleField(don, "nodeP2PIds")
+	if err != nil {
+		return registryDON{}, fmt.Errorf("%s response: %w", getDONMethod, err)
+	}
+	p2pIds, ok := ids.Interface().([][32]byte)
+	if !ok {
+		return registryDON{}, fmt.Errorf("%s response: field nodeP2PIds has type %s, expected bytes32[]", getDONMethod, ids.Type())
+	}
+	return registryDON{configCount: uint32(configCountValue), f: int(fValue), nodeP2PIds: p2pIds}, nil
+}
+
+// decodeSigners decodes the response of getNodesByP2PIds into a map from signer key to node operator ID.
+func decodeSigners(registry abi.ABI, data []byte) (map[SignerKey]uint32, error) {
+	out, err := unpackRegistryResponse(registry, getNodesByP2PIdsMethod, data)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := reflect.ValueOf(out[0])
+	signers := make(map[SignerKey]uint32, nodes.Len())
+	for i := 0; i < nodes.Len(); i++ {
+		node := nodes.Index(i)
+		operatorField, err := tupleField(node, "nodeOperatorId")
+		if err != nil {
+			return nil, fmt.Errorf("%s response: node %d: %w", getNodesByP2PIdsMethod, i, err)
+		}
+		operatorID, err := toUint64(operatorField.Interface(), math.MaxUint32)
+		if err != nil {
+			return nil, fmt.Errorf("%s response: node %d: field nodeOperatorId: %w", getNodesByP2PIdsMethod, i, err)
+		}
+
+		signerField, err := tupleField(node, "signer")
+		if err != nil {
+			return nil, fmt.Errorf("%s response: node %d: %w", getNodesByP2PIdsMethod, i, err)
+		}
+		signer, ok := signerField.Interface().([32]byte)
+		if !ok {
+			return nil, fmt.Errorf("%s response: node %d: field signer has type %s, expected bytes32", getNodesByP2PIdsMethod, i, signerField.Type())
+		}
+
+		signers[signer] = uint32(operatorID)
+	}
+	return signers, nil
+}
+
+// unpackRegistryResponse decodes the outputs of a registry method.
+// Unlike abi.ABI.Unpack, a response of the wrong length is reported without its contents.
+func unpackRegistryResponse(registry abi.ABI, method string, data []byte) ([]any, error) {
+	if len(data)%32 != 0 {
+		return nil, fmt.Errorf("cannot decode %s response: %d bytes is not a multiple of 32", method, len(data))
+	}
+
+	out, err := registry.Methods[method].Outputs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s response of %d bytes: %w", method, len(data), err)
+	}
+	return out, nil
+}
+
+// tupleField returns a field of a tuple decoded by the abi package, by its name in the ABI.
+func tupleField(tuple reflect.Value, name string) (reflect.Value, error) {
+	if tuple.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("expected a tuple, got %s", tuple.Type())
+	}
+	field := tuple.FieldByName(abi.ToCamelCase(name))
+	if !field.IsValid() {
+		return reflect.Value{}, fmt.Errorf("missing field %s", name)
+	}
+	return field, nil
+}
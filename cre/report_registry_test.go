@@ -0,0 +1,107 @@
+package cre
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-sdk-go/internal/capreg"
+)
+
+type testRegistryNode struct {
+	NodeOperatorId      uint32
+	ConfigCount         uint32
+	WorkflowDONId       uint32
+	Signer              [32]byte
+	P2pId               [32]byte
+	EncryptionPublicKey [32]byte
+	CsaKey              [32]byte
+	CapabilityIds       []string
+	CapabilitiesDONIds  []*big.Int
+}
+
+func TestDecodeSigners(t *testing.T) {
+	registry, err := registryABI(Environment{})
+	require.NoError(t, err)
+
+	signer1 := common.HexToAddress("0x4D6CFd44F94408a39fB1af94a53c107A730ba161")
+	signer2 := common.HexToAddress("0x1A89c98E75983Ec384AD8e83EAf7D0176eEaF155")
+	nodes := []testRegistryNode{
+		{NodeOperatorId: 10, Signer: ECDSASignerKey(signer1), CapabilityIds: []string{"a@1.0.0"}, CapabilitiesDONIds: []*big.Int{big.NewInt(1)}},
+		{NodeOperatorId: 4, Signer: ECDSASignerKey(signer2)},
+	}
+	data, err := registry.Methods[getNodesByP2PIdsMethod].Outputs.Pack(nodes)
+	require.NoError(t, err)
+
+	t.Run("valid response", func(t *testing.T) {
+		signers, err := decodeSigners(registry, data)
+		require.NoError(t, err)
+		assert.Equal(t, map[SignerKey]uint32{ECDSASignerKey(signer1): 10, ECDSASignerKey(signer2): 4}, signers)
+	})
+
+	t.Run("truncated response", func(t *testing.T) {
+		_, err := decodeSigners(registry, data[:len(data)-64])
+		assert.ErrorContains(t, err, "cannot decode getNodesByP2PIds response of")
+	})
+
+	t.Run("response that is not a multiple of 32 bytes", func(t *testing.T) {
+		_, err := decodeSigners(registry, data[:len(data)-1])
+		assert.ErrorContains(t, err, "is not a multiple of 32")
+	})
+}
+
+func TestDecodeDON(t *testing.T) {
+	registry, err := registryABI(Environment{})
+	require.NoError(t, err)
+
+	t.Run("truncated response", func(t *testing.T) {
+		_, err := decodeDON(registry, make([]byte, 64))
+		assert.ErrorContains(t, err, "cannot decode getDON response of 64 bytes")
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		_, err := decodeDON(registry, nil)
+		assert.ErrorContains(t, err, "cannot decode getDON response")
+	})
+}
+
+func TestRegisterRegistryABI(t *testing.T) {
+	t.Run("registered versions are used by environments", func(t *testing.T) {
+		require.NoError(t, RegisterRegistryABI("test-version", capreg.V2ABI))
+		_, err := registryABI(Environment{RegistryABIVersion: "test-version"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := registryABI(Environment{RegistryABIVersion: "unknown"})
+		assert.ErrorContains(t, err, `unknown registry ABI version "unknown"`)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		assert.Error(t, RegisterRegistryABI("invalid", "not json"))
+	})
+
+	t.Run("missing method", func(t *testing.T) {
+		err := RegisterRegistryABI("missing", `[{"type":"function","name":"getDON","inputs":[],"outputs":[]}]`)
+		assert.ErrorContains(t, err, "method getDON has unexpected outputs")
+	})
+}
+
+func TestBlockConfidence(t *testing.T) {
+	for confidence, expected := range map[BlockConfidence]int64{
+		"":                       -3,
+		BlockConfidenceFinalized: -3,
+		BlockConfidenceSafe:      -4,
+		BlockConfidenceLatest:    -2,
+	} {
+		actual, err := confidence.blockNumber()
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+
+	_, err := BlockConfidence("soon").blockNumber()
+	assert.ErrorContains(t, err, `unknown block confidence "soon"`)
+}
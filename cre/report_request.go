@@ -0,0 +1,105 @@
+package cre
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const (
+	// EncoderEVM is the encoder of reports written to EVM forwarder contracts.
+	EncoderEVM = "evm"
+	// EncoderSolana is the encoder of reports written to the Solana forwarder program.
+	EncoderSolana = "solana"
+
+	// HashingAlgorithmKeccak256 is the hashing algorithm paired with SigningAlgorithmECDSA.
+	HashingAlgorithmKeccak256 = "keccak256"
+	// HashingAlgorithmSHA256 is the hashing algorithm paired with SigningAlgorithmEd25519.
+	HashingAlgorithmSHA256 = "sha256"
+)
+
+// ReportEncoding is the combination of encoder, signing and hashing algorithms used to produce a report.
+type ReportEncoding struct {
+	EncoderName      string
+	SigningAlgorithm string
+	HashingAlgorithm string
+}
+
+var (
+	// EVMReportEncoding produces reports accepted by EVM forwarder contracts.
+	EVMReportEncoding = ReportEncoding{EncoderName: EncoderEVM, SigningAlgorithm: SigningAlgorithmECDSA, HashingAlgorithm: HashingAlgorithmKeccak256}
+	// SolanaReportEncoding produces reports for the Solana forwarder program.
+	// It is experimental: the algorithms have not yet been confirmed against the forwarder and may change.
+	SolanaReportEncoding = ReportEncoding{EncoderName: EncoderSolana, SigningAlgorithm: SigningAlgorithmEd25519, HashingAlgorithm: HashingAlgorithmSHA256}
+)
+
+// supportedReportEncodings are the combinations that forwarders can verify.
+var supportedReportEncodings = []ReportEncoding{EVMReportEncoding, SolanaReportEncoding}
+
+// BorshMarshaler is implemented by Borsh-serializable values, such as ForwarderReport in the Solana capability's bindings package.
+type BorshMarshaler interface {
+	Marshal() ([]byte, error)
+}
+
+// EVMReportRequest ABI-encodes values as args for a report written to an EVM forwarder contract.
+func EVMReportRequest(args abi.Arguments, values ...any) (*ReportRequest, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: no ABI arguments", ErrInvalidReportRequest)
+	}
+
+	payload, err := args.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to ABI-encode payload: %w", ErrInvalidReportRequest, err)
+	}
+	return RawReportRequest(payload, EVMReportEncoding)
+}
+
+// SolanaReportRequest Borsh-encodes value for a report written to the Solana forwarder program.
+// It uses SolanaReportEncoding, which is experimental.
+func SolanaReportRequest(value BorshMarshaler) (*ReportRequest, error) {
+	if value == nil {
+		return nil, fmt.Errorf("%w: no Borsh value", ErrInvalidReportRequest)
+	}
+
+	payload, err := value.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to Borsh-encode payload: %w", ErrInvalidReportRequest, err)
+	}
+	return RawReportRequest(payload, SolanaReportEncoding)
+}
+
+// RawReportRequest creates a report request for an already encoded payload.
+// The encoding must be one that forwarders can verify, such as EVMReportEncoding or SolanaReportEncoding.
+func RawReportRequest(payload []byte, encoding ReportEncoding) (*ReportRequest, error) {
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("%w: empty payload", ErrInvalidReportRequest)
+	}
+
+	if err := encoding.validate(); err != nil {
+		return nil, err
+	}
+
+	return &ReportRequest{
+		EncodedPayload: payload,
+		EncoderName:    encoding.EncoderName,
+		SigningAlgo:    encoding.SigningAlgorithm,
+		HashingAlgo:    encoding.HashingAlgorithm,
+	}, nil
+}
+
+func (e ReportEncoding) validate() error {
+	for _, supported := range supportedReportEncodings {
+		if e == supported {
+			return nil
+		}
+	}
+
+	for _, supported := range supportedReportEncodings {
+		if e.EncoderName == supported.EncoderName {
+			return fmt.Errorf(
+				"%w: encoder %q requires signing algorithm %q and hashing algorithm %q, got %q and %q",
+				ErrInvalidReportRequest, e.EncoderName, supported.SigningAlgorithm, supported.HashingAlgorithm, e.SigningAlgorithm, e.HashingAlgorithm)
+		}
+	}
+	return fmt.Errorf("%w: unknown encoder %q", ErrInvalidReportRequest, e.EncoderName)
+}
@@ -0,0 +1,130 @@
+package cre_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EVMReportRequest(t *testing.T) {
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	require.NoError(t, err)
+	addressType, err := abi.NewType("address", "", nil)
+	require.NoError(t, err)
+	args := abi.Arguments{{Type: uint256Type}, {Type: addressType}}
+
+	t.Run("encodes values", func(t *testing.T) {
+		owner := common.HexToAddress("0x4D6CFd44F94408a39fB1af94a53c107A730ba161")
+
+		request, err := cre.EVMReportRequest(args, big.NewInt(42), owner)
+
+		require.NoError(t, err)
+		expected, err := args.Pack(big.NewInt(42), owner)
+		require.NoError(t, err)
+		assert.Equal(t, expected, request.EncodedPayload)
+		assert.Equal(t, cre.EncoderEVM, request.EncoderName)
+		assert.Equal(t, cre.SigningAlgorithmECDSA, request.SigningAlgo)
+		assert.Equal(t, cre.HashingAlgorithmKeccak256, request.HashingAlgo)
+	})
+
+	t.Run("values do not match arguments", func(t *testing.T) {
+		_, err := cre.EVMReportRequest(args, big.NewInt(42))
+
+		require.ErrorIs(t, err, cre.ErrInvalidReportRequest)
+		assert.ErrorContains(t, err, "failed to ABI-encode payload")
+	})
+
+	t.Run("no arguments", func(t *testing.T) {
+		_, err := cre.EVMReportRequest(nil)
+
+		require.ErrorIs(t, err, cre.ErrInvalidReportRequest)
+	})
+}
+
+func Test_SolanaReportRequest(t *testing.T) {
+	t.Run("encodes value", func(t *testing.T) {
+		report := forwarderReport{AccountHash: [32]byte{1}, Payload: []byte("hello solana")}
+		request, err := cre.SolanaReportRequest(report)
+
+		require.NoError(t, err)
+		expected, err := report.Marshal()
+		require.NoError(t, err)
+		assert.Equal(t, expected, request.EncodedPayload)
+		assert.Equal(t, cre.EncoderSolana, request.EncoderName)
+		assert.Equal(t, cre.SigningAlgorithmEd25519, request.SigningAlgo)
+		assert.Equal(t, cre.HashingAlgorithmSHA256, request.HashingAlgo)
+	})
+
+	t.Run("encoding error", func(t *testing.T) {
+		_, err := cre.SolanaReportRequest(borshValue{err: errors.New("nope")})
+
+		require.ErrorIs(t, err, cre.ErrInvalidReportRequest)
+		assert.ErrorContains(t, err, "nope")
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		_, err := cre.SolanaReportRequest(nil)
+
+		require.ErrorIs(t, err, cre.ErrInvalidReportRequest)
+	})
+}
+
+func Test_RawReportRequest(t *testing.T) {
+	t.Run("supported encoding", func(t *testing.T) {
+		request, err := cre.RawReportRequest([]byte("payload"), cre.EVMReportEncoding)
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("payload"), request.EncodedPayload)
+		assert.Equal(t, cre.EncoderEVM, request.EncoderName)
+	})
+
+	t.Run("mismatched algorithms", func(t *testing.T) {
+		encoding := cre.EVMReportEncoding
+		encoding.SigningAlgorithm = cre.SigningAlgorithmEd25519
+
+		_, err := cre.RawReportRequest([]byte("payload"), encoding)
+
+		require.ErrorIs(t, err, cre.ErrInvalidReportRequest)
+		assert.ErrorContains(t, err, `encoder "evm" requires signing algorithm "ecdsa" and hashing algorithm "keccak256", got "ed25519" and "keccak256"`)
+	})
+
+	t.Run("unknown encoder", func(t *testing.T) {
+		_, err := cre.RawReportRequest([]byte("payload"), cre.ReportEncoding{EncoderName: "xml"})
+
+		require.ErrorIs(t, err, cre.ErrInvalidReportRequest)
+		assert.ErrorContains(t, err, `unknown encoder "xml"`)
+	})
+
+	t.Run("empty payload", func(t *testing.T) {
+		_, err := cre.RawReportRequest(nil, cre.SolanaReportEncoding)
+
+		require.ErrorIs(t, err, cre.ErrInvalidReportRequest)
+	})
+}
+
+// forwarderReport mirrors ForwarderReport from the Solana capability's bindings package:
+// account_hash(32) | payload_len(u32 LE) | payload.
+type forwarderReport struct {
+	AccountHash [32]byte
+	Payload     []byte
+}
+
+func (r forwarderReport) Marshal() ([]byte, error) {
+	return append(binary.LittleEndian.AppendUint32(r.AccountHash[:], uint32(len(r.Payload))), r.Payload...), nil
+}
+
+type borshValue struct {
+	data []byte
+	err  error
+}
+
+func (b borshValue) Marshal() ([]byte, error) {
+	return b.data, b.err
+}
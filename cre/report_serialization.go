@@ -0,0 +1,101 @@
+package cre
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"google.golang.org/protobuf/proto"
+)
+
+// reportEnvelope is the JSON encoding of a Report.
+// Signer IDs are not part of it: they are only known once the signatures are verified, so a receiver must not trust them.
+type reportEnvelope struct {
+	RawReport     []byte   `json:"rawReport"`
+	ReportContext []byte   `json:"reportContext"`
+	Signatures    [][]byte `json:"signatures"`
+}
+
+// MarshalJSON encodes the raw report, report context and signatures as a JSON object, with bytes in base64.
+// It can be sent to a service, or another workflow's HTTP trigger, and decoded with ParseReportEnvelope.
+func (r *Report) MarshalJSON() ([]byte, error) {
+	return json.Marshal(reportEnvelope{
+		RawReport:     r.RawReport(),
+		ReportContext: r.ReportContext(),
+		Signatures:    r.Signatures(),
+	})
+}
+
+// MarshalBinary encodes the raw report, report context and signatures as an sdk.ReportResponse protobuf,
+// without signer IDs. It is decoded with ParseReportEnvelope.
+func (r *Report) MarshalBinary() ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: true}.Marshal(&sdk.ReportResponse{
+		RawReport:     r.RawReport(),
+		ReportContext: r.ReportContext(),
+		Sigs:          unattributedSigs(r.Signatures()),
+	})
+}
+
+// UnmarshalJSON decodes a report encoded by MarshalJSON. The signatures are not verified.
+func (r *Report) UnmarshalJSON(data []byte) error {
+	var envelope reportEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("cannot decode report: %w", err)
+	}
+	return r.set(newReport(envelope.RawReport, envelope.Signatures, envelope.ReportContext))
+}
+
+// UnmarshalBinary decodes a report encoded by MarshalBinary. The signatures are not verified,
+// and any signer IDs in the data are dropped.
+func (r *Report) UnmarshalBinary(data []byte) error {
+	response := &sdk.ReportResponse{}
+	if err := proto.Unmarshal(data, response); err != nil {
+		return fmt.Errorf("cannot decode report: %w", err)
+	}
+
+	sigs := make([][]byte, len(response.GetSigs()))
+	for i, sig := range response.GetSigs() {
+		sigs[i] = sig.GetSignature()
+	}
+	return r.set(newReport(response.GetRawReport(), sigs, response.GetReportContext()))
+}
+
+// ParseReportEnvelope decodes a report encoded by Report.MarshalJSON or Report.MarshalBinary,
+// and verifies it as specified by the config, like ParseReportWithConfig.
+func ParseReportEnvelope(runtime Runtime, data []byte, config ReportParseConfig) (*Report, error) {
+	report, err := UnmarshalReport(data)
+	if err != nil {
+		return nil, err
+	}
+	return parseReport(runtime, report, config)
+}
+
+// UnmarshalReport decodes a report encoded by Report.MarshalJSON or Report.MarshalBinary.
+// The signatures are not verified, use ParseReportEnvelope to decode and verify a report.
+func UnmarshalReport(data []byte) (*Report, error) {
+	report := &Report{}
+	var err error
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		err = report.UnmarshalJSON(data)
+	} else {
+		err = report.UnmarshalBinary(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// set replaces the report with decoded, and checks its header.
+func (r *Report) set(decoded *Report) error {
+	if _, err := decoded.parseHeader(); err != nil {
+		return err
+	}
+
+	r.headerLock.Lock()
+	defer r.headerLock.Unlock()
+	r.report = decoded.report
+	r.cachedHeader = decoded.cachedHeader
+	return nil
+}
@@ -0,0 +1,112 @@
+package cre_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+)
+
+func TestReport_Serialization(t *testing.T) {
+	don, sigs := newStaticDON(t, reportDONID, 1, 3)
+	report, err := cre.VerifyReportOffline(rawReport, sigs, reportContext, don)
+	require.NoError(t, err)
+
+	t.Run("JSON round-trips through an HTTP trigger input", func(t *testing.T) {
+		// The HTTP trigger's Payload.Input holds the request body as bytes.
+		input, err := json.Marshal(report)
+		require.NoError(t, err)
+
+		decoded, err := cre.UnmarshalReport(input)
+		require.NoError(t, err)
+		assertSameReport(t, report, decoded)
+
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		config := cre.ReportParseConfig{StaticDONs: []cre.StaticDON{don}}
+		parsed, err := cre.ParseReportEnvelope(runtime, input, config)
+		require.NoError(t, err)
+		assert.Equal(t, reportBody, string(parsed.Body()))
+		assert.Equal(t, uint32(100), parsed.X_GeneratedCodeOnly_Unwrap().Sigs[0].SignerId)
+	})
+
+	t.Run("parsing verifies the envelope", func(t *testing.T) {
+		otherDON, _ := newStaticDON(t, reportDONID, 1, 3)
+		encoded, err := json.Marshal(report)
+		require.NoError(t, err)
+
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		_, err = cre.ParseReportEnvelope(runtime, encoded, cre.ReportParseConfig{StaticDONs: []cre.StaticDON{otherDON}})
+		require.Error(t, err)
+
+		_, err = cre.ParseReportEnvelope(runtime, []byte(`{"rawReport": "AAEC"}`), cre.ReportParseConfig{})
+		require.ErrorIs(t, err, cre.ErrRawReportTooShort)
+	})
+
+	t.Run("JSON envelope", func(t *testing.T) {
+		encoded, err := json.Marshal(report)
+		require.NoError(t, err)
+
+		var envelope map[string]any
+		require.NoError(t, json.Unmarshal(encoded, &envelope))
+		assert.ElementsMatch(t, []string{"rawReport", "reportContext", "signatures"}, keys(envelope))
+		signatures := envelope["signatures"].([]any)
+		require.Len(t, signatures, 2)
+		assert.IsType(t, "", signatures[0], "signer IDs are not trusted, so only the signatures are encoded")
+	})
+
+	t.Run("JSON in a struct", func(t *testing.T) {
+		type message struct {
+			Report *cre.Report `json:"report"`
+		}
+
+		encoded, err := json.Marshal(message{Report: report})
+		require.NoError(t, err)
+
+		var decoded message
+		require.NoError(t, json.Unmarshal(encoded, &decoded))
+		assertSameReport(t, report, decoded.Report)
+	})
+
+	t.Run("binary round trip", func(t *testing.T) {
+		encoded, err := report.MarshalBinary()
+		require.NoError(t, err)
+
+		decoded, err := cre.UnmarshalReport(encoded)
+		require.NoError(t, err)
+		assertSameReport(t, report, decoded)
+	})
+
+	t.Run("invalid report", func(t *testing.T) {
+		_, err := cre.UnmarshalReport([]byte(`{"rawReport": "AAEC"}`))
+		require.ErrorIs(t, err, cre.ErrRawReportTooShort)
+
+		_, err = cre.UnmarshalReport([]byte(`{"rawReport": 1}`))
+		require.ErrorContains(t, err, "cannot decode report")
+	})
+}
+
+func assertSameReport(t *testing.T, expected, actual *cre.Report) {
+	t.Helper()
+
+	assert.Equal(t, expected.RawReport(), actual.RawReport())
+	assert.Equal(t, expected.ReportContext(), actual.ReportContext())
+	assert.Equal(t, expected.Signatures(), actual.Signatures())
+	assert.Equal(t, expected.ConfigDigest(), actual.ConfigDigest())
+	assert.Equal(t, expected.SeqNr(), actual.SeqNr())
+	assert.Equal(t, expected.ExecutionID(), actual.ExecutionID())
+	for _, sig := range actual.X_GeneratedCodeOnly_Unwrap().Sigs {
+		assert.Zero(t, sig.SignerId, "signer IDs are only set by verification")
+	}
+}
+
+func keys(m map[string]any) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
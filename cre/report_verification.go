@@ -8,26 +8,29 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
-var keyCache = &sync.Map{}
-
 type donInfo struct {
-	f int
-	// signers maps signer address → nodeOperatorId (slot 0 of NodeInfo ABI tuple).
-	signers map[common.Address]uint32
+	// configCount is the DON config version the registry returned the signers for.
+	configCount uint32
+	f           int
+	// signers maps signer key (slot 3 of NodeInfo ABI tuple) → nodeOperatorId (slot 0).
+	signers map[SignerKey]uint32
 }
 
 type Environment struct {
 	ChainSelector   uint64
 	RegistryAddress string
+	// RegistryABIVersion is the version of the capabilities registry ABI, see RegisterRegistryABI. Empty means RegistryABIV2.
+	RegistryABIVersion string
+	// BlockConfidence is the block the registry is read at. Empty means BlockConfidenceFinalized.
+	BlockConfidence BlockConfidence
 }
 
 // Zone is a specific CRE instance that has its own workflow DON.
@@ -50,9 +53,68 @@ func ZoneFromEnvironment(env Environment, donId uint32) Zone {
 	return Zone{Environment: env, DonID: donId}
 }
 
+// StaticDON is a DON whose signers are known ahead of time.
+// Reports from a StaticDON can be verified without reading the DON from the capabilities registry, see
+// ReportParseConfig.StaticDONs and VerifyReportOffline.
+type StaticDON struct {
+	DonID uint32
+	F     int
+	// Signers maps each ECDSA signer address to the ID of the node operator running the node.
+	Signers map[common.Address]uint32
+	// SignerKeys maps the keys of signers using other signing algorithms, such as ed25519 public keys, to the ID of
+	// the node operator running the node.
+	SignerKeys map[SignerKey]uint32
+	// SigningAlgorithm is the DON's signing algorithm. Empty means the ReportParseConfig's, or SigningAlgorithmECDSA
+	// for VerifyReportOffline.
+	SigningAlgorithm string
+}
+
+func (d StaticDON) signerKeys() map[SignerKey]uint32 {
+	keys := make(map[SignerKey]uint32, len(d.Signers)+len(d.SignerKeys))
+	for address, operatorID := range d.Signers {
+		keys[ECDSASignerKey(address)] = operatorID
+	}
+	for key, operatorID := range d.SignerKeys {
+		keys[key] = operatorID
+	}
+	return keys
+}
+
+func (d StaticDON) verifier(defaultAlgorithm string) (ReportVerifier, error) {
+	if d.SigningAlgorithm != "" {
+		return reportVerifier(d.SigningAlgorithm)
+	}
+	return reportVerifier(defaultAlgorithm)
+}
+
 type ReportParseConfig struct {
 	AcceptedZones        []Zone
 	AcceptedEnvironments []Environment
+	// StaticDONs are checked before any zone or environment, without reading from the chain.
+	// If the report's DON is not a StaticDON, or its signatures do not match, the accepted zones and environments are checked.
+	StaticDONs []StaticDON
+	// SigningAlgorithm selects the verifier for the report's signatures, see RegisterReportVerifier.
+	// Empty means SigningAlgorithmECDSA.
+	SigningAlgorithm string
+	// MaxAge rejects reports whose timestamp is more than MaxAge before runtime.Now(). Zero disables the check.
+	MaxAge time.Duration
+	// AcceptedWorkflowOwners, AcceptedWorkflowIDs, AcceptedWorkflowNames and AcceptedReportIDs reject reports whose
+	// header values are not in the list. Hex values may have a 0x prefix and are compared ignoring case.
+	// Workflow names are the names the workflows were deployed with, they are hashed with WorkflowNameHash before comparing.
+	// An empty list accepts any value.
+	AcceptedWorkflowOwners []string
+	AcceptedWorkflowIDs    []string
+	AcceptedWorkflowNames  []string
+	AcceptedReportIDs      []string
+	// MinDONConfigVersion rejects reports signed with an older DON configuration.
+	MinDONConfigVersion uint32
+	// ReplayGuard, if set, rejects reports whose execution ID and sequence number it has already seen.
+	// Reports are only recorded once all other checks pass.
+	ReplayGuard ReplayGuard
+	// DONCacheTTL is how long DON signers read from the chain are trusted before they are read again.
+	// A report claiming a newer DON config version than the cached one reads the DON again regardless of the TTL.
+	// Zero means cached signers do not expire, use InvalidateDONCache to remove them.
+	DONCacheTTL time.Duration
 	// SkipSignatureVerification skips the signature verification step. This can be used for testing or in environments where trust is established by other means, but should be used with caution as it disables a critical security check.
 	// It should only be used alongside Report.Verify if filtering reports first to avoid unnecessary calls to the blockchain.
 	SkipSignatureVerification bool
@@ -67,13 +129,70 @@ func ParseReport(runtime Runtime, rawReport []byte, signatures [][]byte, reportC
 }
 
 // ParseReportWithConfig parses a CRE report and verifies it as specified by the config.
+// Besides the signatures, the config can restrict the report's age, origin and DON config version, and reject replays.
 // The first time a DON's report is seen, and SkipSignatureVerification is false, the signatures will be fetched from chain. It will be cached for later report parsing.
 func ParseReportWithConfig(runtime Runtime, rawReport []byte, signatures [][]byte, reportContext []byte, config ReportParseConfig) (*Report, error) {
+	return parseReport(runtime, newReport(rawReport, signatures, reportContext), config)
+}
+
+func parseReport(runtime Runtime, report *Report, config ReportParseConfig) (*Report, error) {
+	// Policies are checked first, so reports that would be rejected anyway do not read from the chain.
+	if err := report.checkPolicies(runtime, config); err != nil {
+		return nil, err
+	}
+
+	if !config.SkipSignatureVerification {
+		if err := report.VerifySignaturesWithConfig(runtime, config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := report.checkReplay(config.ReplayGuard); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// VerifyReportOffline parses a CRE report and verifies it was signed by the given DON.
+// It does not need a Runtime, so it can be used outside a workflow, for example by a service receiving reports.
+func VerifyReportOffline(rawReport []byte, signatures [][]byte, reportContext []byte, don StaticDON) (*Report, error) {
+	report := newReport(rawReport, signatures, reportContext)
+	header, err := report.parseHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	if header.donID != don.DonID {
+		return nil, fmt.Errorf("%w: report is from DON %d, expected DON %d", ErrUnexpectedDON, header.donID, don.DonID)
+	}
+
+	verifier, err := don.verifier(SigningAlgorithmECDSA)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = verifySigs(report.report, don.F, don.signerKeys(), verifier); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func newReport(rawReport []byte, signatures [][]byte, reportContext []byte) *Report {
+	return newReportResponse(rawReport, unattributedSigs(signatures), reportContext)
+}
+
+// unattributedSigs wraps signatures whose signers are not known yet. Verification attributes them.
+func unattributedSigs(signatures [][]byte) []*sdk.AttributedSignature {
 	attrSigs := make([]*sdk.AttributedSignature, len(signatures))
 	for i, s := range signatures {
 		attrSigs[i] = &sdk.AttributedSignature{Signature: s}
 	}
+	return attrSigs
+}
 
+func newReportResponse(rawReport []byte, attrSigs []*sdk.AttributedSignature, reportContext []byte) *Report {
 	// Extract ConfigDigest and SeqNr from the report context when present.
 	// Standard layout: bytes 0-31 = ConfigDigest, bytes 32-39 = SeqNr (big-endian uint64).
 	var configDigest []byte
@@ -83,7 +202,7 @@ func ParseReportWithConfig(runtime Runtime, rawReport []byte, signatures [][]byt
 		seqNr = binary.BigEndian.Uint64(reportContext[32:40])
 	}
 
-	report := &Report{
+	return &Report{
 		report: &sdk.ReportResponse{
 			RawReport:     rawReport,
 			Sigs:          attrSigs,
@@ -92,20 +211,6 @@ func ParseReportWithConfig(runtime Runtime, rawReport []byte, signatures [][]byt
 			SeqNr:         seqNr,
 		},
 	}
-
-	if config.SkipSignatureVerification {
-		if _, err := report.parseHeader(); err != nil {
-			return nil, err
-		}
-
-		return report, nil
-	}
-
-	if err := report.VerifySignaturesWithConfig(runtime, config); err != nil {
-		return nil, err
-	}
-
-	return report, nil
 }
 
 // VerifySignatures verifies the signatures on a Report against the CRE's production environment.
@@ -115,7 +220,8 @@ func (r *Report) VerifySignatures(runtime Runtime) error {
 	return r.VerifySignaturesWithConfig(runtime, defaultVerificationConfig)
 }
 
-// VerifySignaturesWithConfig verifies the signatures on a Report against the CRE's production environment.
+// VerifySignaturesWithConfig verifies the signatures on a Report against the DONs, zones and environments in the config.
+// Static DONs are checked first, without reading from the chain.
 // The first time a DON's report is seen, the signatures will be fetched from the chain. It will be cached for later report parsing.
 // VerifySignaturesWithConfig only needs to be called if SkipSignatureVerification was used with ParseReportWithConfig.
 // Note config.SkipSignatureVerification is ignored by this method since if it were true, signatures would not be verified at all.
@@ -126,6 +232,28 @@ func (r *Report) VerifySignaturesWithConfig(runtime Runtime, config ReportParseC
 		return err
 	}
 
+	verifier, err := reportVerifier(config.SigningAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	var sigErr error
+	staticMatch := false
+	for _, don := range config.StaticDONs {
+		if don.DonID != header.donID {
+			continue
+		}
+
+		staticMatch = true
+		staticVerifier, err := don.verifier(config.SigningAlgorithm)
+		if err != nil {
+			return err
+		}
+		if sigErr = verifySigs(r.report, don.F, don.signerKeys(), staticVerifier); sigErr == nil {
+			return nil
+		}
+	}
+
 	var candidates []Environment
 	for _, zone := range config.AcceptedZones {
 		if zone.DonID == header.donID {
@@ -135,13 +263,15 @@ func (r *Report) VerifySignaturesWithConfig(runtime Runtime, config ReportParseC
 	candidates = append(candidates, config.AcceptedEnvironments...)
 
 	if len(candidates) == 0 {
+		if staticMatch {
+			return sigErr
+		}
 		return fmt.Errorf("DON ID %d is not in accepted zones", header.donID)
 	}
 
-	var sigErr error
 	var fetchFailures []error
 	for _, env := range candidates {
-		f, signers, err := fetchDONInfo(runtime, env, header.donID)
+		f, signers, err := fetchDONInfo(runtime, env, header.donID, header.donConfigVersion, config.DONCacheTTL)
 		if err != nil {
 			readErr := fmt.Errorf(
 				"could not read from chain %d contract %s: %w",
@@ -153,7 +283,7 @@ func (r *Report) VerifySignaturesWithConfig(runtime Runtime, config ReportParseC
 			continue
 		}
 
-		if sigErr = verifySigs(r.report, f, signers); sigErr == nil {
+		if sigErr = verifySigs(r.report, f, signers, verifier); sigErr == nil {
 			return nil
 		}
 	}
@@ -171,11 +301,18 @@ func (r *Report) VerifySignaturesWithConfig(runtime Runtime, config ReportParseC
 //  1. getDON(donID)           → DONInfo containing f and nodeP2PIds
 //  2. getNodesByP2PIds(ids)   → NodeInfo[] containing signer bytes32 per node
 //
-// Results are cached per chain+DON.
-func fetchDONInfo(runtime Runtime, env Environment, donID uint32) (int, map[common.Address]uint32, error) {
-	cacheKey := fmt.Sprintf("%d:%d", env.ChainSelector, donID)
-	if cached, ok := keyCache.Load(cacheKey); ok {
-		info := cached.(donInfo)
+// The calls are encoded and decoded with the environment's registry ABI, see RegisterRegistryABI.
+// configVersion is the DON config version claimed by the report; it fails with ErrUnknownDONConfigVersion
+// if it is newer than the registry's configCount for the DON.
+// Results are cached per environment and DON, under the registry's configCount, for up to ttl.
+func fetchDONInfo(runtime Runtime, env Environment, donID, configVersion uint32, ttl time.Duration) (int, map[SignerKey]uint32, error) {
+	cacheKey := newDONCacheKey(env, donID)
+	// Reading the time is a call to the host, so it is only done when entries can expire.
+	var now time.Time
+	if ttl > 0 {
+		now = runtime.Now()
+	}
+	if info, ok := loadDONInfo(cacheKey, configVersion, now, ttl); ok {
 		return info.f, info.signers, nil
 	}
 
@@ -186,145 +323,81 @@ func fetchDONInfo(runtime Runtime, env Environment, donID uint32) (int, map[comm
 		return 0, nil, fmt.Errorf("invalid registry address %q: %w", env.RegistryAddress, err)
 	}
 
+	registry, err := registryABI(env)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	blockNumber, err := env.BlockConfidence.blockNumber()
+	if err != nil {
+		return 0, nil, err
+	}
+
 	capID := "evm:ChainSelector:" + strconv.FormatUint(env.ChainSelector, 10) + "@1.0.0"
 
-	// keccak256("getDON(uint32)")[0:4] = 0x23537405
-	var donIDPadded [32]byte
-	binary.BigEndian.PutUint32(donIDPadded[28:], donID)
-	getDONCallData := append([]byte{0x23, 0x53, 0x74, 0x05}, donIDPadded[:]...)
+	getDONCallData, err := registry.Pack(getDONMethod, donID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("cannot encode %s call: %w", getDONMethod, err)
+	}
 
-	getDONABI, err := callContract(runtime, capID, registryAddr, getDONCallData)
+	getDONABI, err := callContract(runtime, capID, registryAddr, getDONCallData, blockNumber)
 	if err != nil {
 		return 0, nil, err
 	}
 
-	// ABI layout for getDON (tuple with outer pointer at byte 0):
-	//   slot 0  (bytes   0-31):  outer pointer (=32)
-	//   slot 1  (bytes  32-63):  id            (uint32)
-	//   slot 2  (bytes  64-95):  configCount   (uint32)
-	//   slot 3  (bytes  96-127): f             (uint8, zero-padded to 32)
-	//   slot 4  (bytes 128-159): isPublic      (bool)
-	//   slot 5  (bytes 160-191): acceptsWorkflows (bool)
-	//   slot 6  (bytes 192-223): ptr[nodeP2PIds]  (bytes32[])
-	//   slot 7  (bytes 224-255): ptr[donFamilies] (string[])
-	//   slot 8  (bytes 256-287): ptr[name]        (string)
-	//   slot 9  (bytes 288-319): ptr[config]      (bytes)
-	//   slot 10 (bytes 320-351): ptr[capabilityConfigurations] (tuple[])
-	if len(getDONABI) < 224 {
-		return 0, nil, fmt.Errorf("getDON ABI response too short: %d bytes", len(getDONABI))
-	}
-
-	f := int(new(big.Int).SetBytes(getDONABI[96:128]).Int64())
-
-	// Read nodeP2PIds from slot 6.
-	// Pointer is tuple-relative; tuple starts at byte 32.
-	const tupleStart = 32
-	nodeP2PIdsPtr := int(new(big.Int).SetBytes(getDONABI[192:224]).Int64())
-	nodeCountOff := tupleStart + nodeP2PIdsPtr
-	if nodeCountOff+32 > len(getDONABI) {
-		return 0, nil, fmt.Errorf("getDON ABI: nodeP2PIds pointer out of range")
-	}
-	nodeCount := int(new(big.Int).SetBytes(getDONABI[nodeCountOff : nodeCountOff+32]).Int64())
-	if nodeCountOff+32+nodeCount*32 > len(getDONABI) {
-		return 0, nil, fmt.Errorf("getDON ABI: nodeP2PIds data out of range")
-	}
-	nodeP2PIds := make([][]byte, nodeCount)
-	for i := 0; i < nodeCount; i++ {
-		start := nodeCountOff + 32 + i*32
-		id := make([]byte, 32)
-		copy(id, getDONABI[start:start+32])
-		nodeP2PIds[i] = id
-	}
-
-	if nodeCount == 0 {
-		info := donInfo{f: f, signers: nil}
-		keyCache.Store(cacheKey, info)
-		return f, nil, nil
-	}
-
-	// keccak256("getNodesByP2PIds(bytes32[])")[0:4] = 0x05a51966
-	//
-	// ABI-encode bytes32[]: [ptr=32][count][id0]...[idN]
-	var p2pIdsABI []byte
-	p2pIdsABI = append(p2pIdsABI, padUint256(32)...)
-	p2pIdsABI = append(p2pIdsABI, padUint256(uint64(nodeCount))...)
-	for _, id := range nodeP2PIds {
-		p2pIdsABI = append(p2pIdsABI, id...)
-	}
-	getNodesCallData := append([]byte{0x05, 0xa5, 0x19, 0x66}, p2pIdsABI...)
-
-	getNodesABI, err := callContract(runtime, capID, registryAddr, getNodesCallData)
+	don, err := decodeDON(registry, getDONABI)
 	if err != nil {
 		return 0, nil, err
 	}
 
-	// ABI layout for getNodesByP2PIds: NodeInfo[] (tuple array).
-	// Each NodeInfo tuple head (9 slots × 32 bytes = 288 bytes per node):
-	//   slot 0: nodeOperatorId (uint32)
-	//   slot 1: configCount    (uint32)
-	//   slot 2: workflowDONId  (uint32)
-	//   slot 3: signer         (bytes32)  ← address left-aligned (first 20 bytes, rest zero)
-	//   slot 4: p2pId          (bytes32)
-	//   slot 5: encryptionPublicKey (bytes32)
-	//   slot 6: csaKey         (bytes32)
-	//   slot 7: ptr[capabilityIds]    (dynamic)
-	//   slot 8: ptr[capabilitiesDONIds] (dynamic)
-	//
-	// The outer array encoding: [outer-ptr=32][count][tuple0-head][tuple1-head]...
-	if len(getNodesABI) < 64 {
-		return 0, nil, fmt.Errorf("getNodesByP2PIds ABI response too short: %d bytes", len(getNodesABI))
-	}
-
-	// The outer pointer (slot 0) points to the array data relative to byte 0.
-	outerPtr := int(new(big.Int).SetBytes(getNodesABI[0:32]).Int64())
-	if outerPtr+32 > len(getNodesABI) {
-		return 0, nil, fmt.Errorf("getNodesByP2PIds ABI: outer pointer out of range")
-	}
-	returnedCount := int(new(big.Int).SetBytes(getNodesABI[outerPtr : outerPtr+32]).Int64())
-
-	const nodeTupleHeadSize = 288 // 9 slots × 32 bytes
-	signers := make(map[common.Address]uint32, returnedCount)
-	for i := 0; i < returnedCount; i++ {
-		// Each tuple in a dynamic array is referenced via a per-element pointer
-		// stored at outerPtr+32+i*32, which is tuple-relative to outerPtr+32.
-		elemPtrOff := outerPtr + 32 + i*32
-		if elemPtrOff+32 > len(getNodesABI) {
-			break
-		}
-		elemPtr := int(new(big.Int).SetBytes(getNodesABI[elemPtrOff : elemPtrOff+32]).Int64())
-		tupleBase := outerPtr + 32 + elemPtr
-		if tupleBase+nodeTupleHeadSize > len(getNodesABI) {
-			break
-		}
-		// slot 0 of the tuple = nodeOperatorId (uint32).
-		nodeOperatorId := uint32(new(big.Int).SetBytes(getNodesABI[tupleBase : tupleBase+32]).Uint64())
-		// slot 3 of the tuple = signer bytes32; address is the first 20 bytes (left-aligned).
-		signerSlot := tupleBase + 3*32
-		addr := common.BytesToAddress(getNodesABI[signerSlot : signerSlot+20])
-		signers[addr] = nodeOperatorId
+	if configVersion > don.configCount {
+		return 0, nil, fmt.Errorf("%w: report has version %d, registry has %d", ErrUnknownDONConfigVersion, configVersion, don.configCount)
+	}
+
+	if len(don.nodeP2PIds) == 0 {
+		storeDONInfo(cacheKey, now, donInfo{configCount: don.configCount, f: don.f, signers: nil})
+		return don.f, nil, nil
+	}
+
+	getNodesCallData, err := registry.Pack(getNodesByP2PIdsMethod, don.nodeP2PIds)
+	if err != nil {
+		return 0, nil, fmt.Errorf("cannot encode %s call: %w", getNodesByP2PIdsMethod, err)
 	}
 
-	info := donInfo{f: f, signers: signers}
+	getNodesABI, err := callContract(runtime, capID, registryAddr, getNodesCallData, blockNumber)
+	if err != nil {
+		return 0, nil, err
+	}
 
-	keyCache.Store(cacheKey, info)
-	return f, signers, nil
+	signers, err := decodeSigners(registry, getNodesABI)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	storeDONInfo(cacheKey, now, donInfo{configCount: don.configCount, f: don.f, signers: signers})
+	return don.f, signers, nil
 }
 
-// callContract sends a CallContractRequest via the EVM capability and returns
+// callContract sends a CallContractRequest via the EVM capability, at the given special block number, and returns
 // the raw ABI-encoded response bytes.
-func callContract(runtime Runtime, capID string, registryAddr []byte, callData []byte) ([]byte, error) {
+func callContract(runtime Runtime, capID string, registryAddr []byte, callData []byte, blockNumber int64) ([]byte, error) {
 	var callMsgBytes []byte
 	callMsgBytes = protowire.AppendTag(callMsgBytes, 2, protowire.BytesType)
 	callMsgBytes = protowire.AppendBytes(callMsgBytes, registryAddr)
 	callMsgBytes = protowire.AppendTag(callMsgBytes, 3, protowire.BytesType)
 	callMsgBytes = protowire.AppendBytes(callMsgBytes, callData)
 
-	// BigInt for FinalizedBlockNumber (-3): {abs_val: [3], sign: int64(-1)}
+	// BigInt for the block number, e.g. FinalizedBlockNumber (-3): {abs_val: [3], sign: int64(-1)}
+	absVal := new(big.Int).Abs(big.NewInt(blockNumber)).Bytes()
+	sign := int64(1)
+	if blockNumber < 0 {
+		sign = -1
+	}
 	var bigIntBytes []byte
 	bigIntBytes = protowire.AppendTag(bigIntBytes, 1, protowire.BytesType)
-	bigIntBytes = protowire.AppendBytes(bigIntBytes, []byte{0x03})
+	bigIntBytes = protowire.AppendBytes(bigIntBytes, absVal)
 	bigIntBytes = protowire.AppendTag(bigIntBytes, 2, protowire.VarintType)
-	bigIntBytes = protowire.AppendVarint(bigIntBytes, 0xFFFFFFFFFFFFFFFF) // int64(-1)
+	bigIntBytes = protowire.AppendVarint(bigIntBytes, uint64(sign))
 
 	// CallContractRequest: field 2 (block_number) before field 1 (call).
 	var reqBytes []byte
@@ -361,13 +434,6 @@ func callContract(runtime Runtime, capID string, registryAddr []byte, callData [
 	}
 }
 
-// padUint256 encodes a uint64 as a big-endian 32-byte value.
-func padUint256(v uint64) []byte {
-	b := make([]byte, 32)
-	binary.BigEndian.PutUint64(b[24:], v)
-	return b
-}
-
 // decodeCallContractReplyData extracts the ABI-encoded data bytes from the
 // proto-encoded CallContractReply (field 1 = data bytes).
 func decodeCallContractReplyData(protoBytes []byte) ([]byte, error) {
@@ -415,7 +481,7 @@ func decodeCallContractReplyData(protoBytes []byte) ([]byte, error) {
 // first f+1 valid signatures from that list are accepted regardless of how
 // many total signatures are present. When no signer list is available (e.g.
 // an older contract version), exactly f+1 valid unique signatures are required.
-func verifySigs(report *sdk.ReportResponse, f int, authorizedSigners map[common.Address]uint32) error {
+func verifySigs(report *sdk.ReportResponse, f int, authorizedSigners map[SignerKey]uint32, verifier ReportVerifier) error {
 	required := f + 1
 	sigs := report.GetSigs()
 
@@ -423,12 +489,11 @@ func verifySigs(report *sdk.ReportResponse, f int, authorizedSigners map[common.
 		return fmt.Errorf("%w: got %d, need at least %d (f+1)", ErrWrongSignatureCount, len(sigs), required)
 	}
 
-	reportHash := crypto.Keccak256Hash(
-		append(crypto.Keccak256(report.GetRawReport()), report.GetReportContext()...),
-	)
+	digest := verifier.Digest(report.GetRawReport(), report.GetReportContext())
 
-	seen := make(map[common.Address]bool, len(sigs))
+	seen := make(map[SignerKey]bool, len(sigs))
 	accepted := make([]*sdk.AttributedSignature, 0, required)
+	signerIds := make([]uint32, 0, required)
 	var skipErrs []error
 
 	for i, attrSig := range sigs {
@@ -436,41 +501,26 @@ func verifySigs(report *sdk.ReportResponse, f int, authorizedSigners map[common.
 			break
 		}
 
-		sigBytes := make([]byte, len(attrSig.GetSignature()))
-		copy(sigBytes, attrSig.GetSignature())
-
-		if len(sigBytes) != 65 {
-			skipErrs = append(skipErrs, fmt.Errorf("index %d: %w: has %d bytes, expected 65",
-				i, ErrParseSignature, len(sigBytes)))
-			continue
-		}
-
-		// Normalise legacy Ethereum v values (27/28 → 0/1).
-		if sigBytes[64] == 27 || sigBytes[64] == 28 {
-			sigBytes[64] -= 27
-		}
-
-		pubKey, err := crypto.SigToPub(reportHash.Bytes(), sigBytes)
+		signer, err := verifier.Signer(digest, attrSig.GetSignature(), authorizedSigners)
 		if err != nil {
-			skipErrs = append(skipErrs, fmt.Errorf("index %d: %w: %s", i, ErrRecoverSigner, err))
+			skipErrs = append(skipErrs, fmt.Errorf("index %d: %w", i, err))
 			continue
 		}
 
-		signer := crypto.PubkeyToAddress(*pubKey)
 		if seen[signer] {
-			skipErrs = append(skipErrs, fmt.Errorf("index %d: %w: %s", i, ErrDuplicateSigner, signer.Hex()))
+			skipErrs = append(skipErrs, fmt.Errorf("index %d: %w: %x", i, ErrDuplicateSigner, signer))
 			continue
 		}
 		seen[signer] = true
 
 		nodeOperatorId, ok := authorizedSigners[signer]
 		if !ok {
-			skipErrs = append(skipErrs, fmt.Errorf("index %d: %w: %s", i, ErrUnknownSigner, signer.Hex()))
+			skipErrs = append(skipErrs, fmt.Errorf("index %d: %w: %x", i, ErrUnknownSigner, signer))
 			continue
 		}
-		attrSig.SignerId = nodeOperatorId
 
 		accepted = append(accepted, attrSig)
+		signerIds = append(signerIds, nodeOperatorId)
 	}
 
 	if len(accepted) < required {
@@ -480,6 +530,11 @@ func verifySigs(report *sdk.ReportResponse, f int, authorizedSigners map[common.
 		return fmt.Errorf("%w: only %d valid, need %d (f+1)", ErrWrongSignatureCount, len(accepted), required)
 	}
 
+	// Only attribute the signatures once verification succeeded, so a failed attempt leaves the report unchanged.
+	for i, attrSig := range accepted {
+		attrSig.SignerId = signerIds[i]
+	}
+
 	// Replace Sigs with only the accepted f+1 entries.
 	report.Sigs = accepted
 	return nil
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
 	"github.com/smartcontractkit/cre-sdk-go/cre"
 	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
@@ -463,3 +465,144 @@ func Test_ParseReportWithConfig(t *testing.T) {
 		require.ErrorContains(t, err, "invalid registry address \""+invalidAddress+"\"")
 	})
 }
+
+func Test_StaticDONs(t *testing.T) {
+	t.Run("valid report without reading from chain", func(t *testing.T) {
+		mock := setupDonSettingRead(t, productionEnvironmentReplay)
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		don, sigs := newStaticDON(t, reportDONID, 1, 3)
+
+		config := cre.ReportParseConfig{
+			AcceptedEnvironments: []cre.Environment{cre.ProductionEnvironment()},
+			StaticDONs:           []cre.StaticDON{don},
+		}
+		report, err := cre.ParseReportWithConfig(runtime, rawReport, sigs, reportContext, config)
+
+		require.NoError(t, err)
+		require.Len(t, report.X_GeneratedCodeOnly_Unwrap().Sigs, 2)
+		assert.Equal(t, uint32(100), report.X_GeneratedCodeOnly_Unwrap().Sigs[0].SignerId)
+		assert.Equal(t, 0, mock.actualCalls)
+	})
+
+	t.Run("falls back to the chain when static signers do not match", func(t *testing.T) {
+		setupDonSettingRead(t, productionEnvironmentReplay)
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		don, _ := newStaticDON(t, reportDONID, 1, 3)
+
+		config := cre.ReportParseConfig{
+			AcceptedEnvironments: []cre.Environment{cre.ProductionEnvironment()},
+			StaticDONs:           []cre.StaticDON{don},
+		}
+		report, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+
+		require.NoError(t, err)
+		require.NotNil(t, report)
+	})
+
+	t.Run("static signature errors returned without other candidates", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		don, _ := newStaticDON(t, reportDONID, 1, 3)
+
+		config := cre.ReportParseConfig{StaticDONs: []cre.StaticDON{don}}
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+
+		require.ErrorIs(t, err, cre.ErrUnknownSigner)
+	})
+
+	t.Run("static DON for another DON ID is not used", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		don, sigs := newStaticDON(t, reportDONID+1, 1, 3)
+
+		config := cre.ReportParseConfig{StaticDONs: []cre.StaticDON{don}}
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, sigs, reportContext, config)
+
+		require.ErrorContains(t, err, "DON ID 1 is not in accepted zones")
+	})
+}
+
+func Test_VerifyReportOffline(t *testing.T) {
+	t.Run("valid report", func(t *testing.T) {
+		don, sigs := newStaticDON(t, reportDONID, 1, 3)
+
+		report, err := cre.VerifyReportOffline(rawReport, sigs, reportContext, don)
+
+		require.NoError(t, err)
+		assert.Equal(t, reportDONID, report.DONID())
+		assert.Equal(t, []byte(reportBody), report.Body())
+	})
+
+	t.Run("too few signatures", func(t *testing.T) {
+		don, sigs := newStaticDON(t, reportDONID, 1, 3)
+
+		_, err := cre.VerifyReportOffline(rawReport, sigs[:1], reportContext, don)
+
+		require.ErrorIs(t, err, cre.ErrWrongSignatureCount)
+	})
+
+	t.Run("unknown signer", func(t *testing.T) {
+		don, _ := newStaticDON(t, reportDONID, 1, 3)
+
+		_, err := cre.VerifyReportOffline(rawReport, reportSigs, reportContext, don)
+
+		require.ErrorIs(t, err, cre.ErrUnknownSigner)
+	})
+
+	t.Run("wrong DON", func(t *testing.T) {
+		don, sigs := newStaticDON(t, reportDONID+1, 1, 3)
+
+		_, err := cre.VerifyReportOffline(rawReport, sigs, reportContext, don)
+
+		require.ErrorIs(t, err, cre.ErrUnexpectedDON)
+	})
+
+	t.Run("invalid report", func(t *testing.T) {
+		don, sigs := newStaticDON(t, reportDONID, 1, 3)
+
+		_, err := cre.VerifyReportOffline([]byte("Not a report"), sigs, reportContext, don)
+
+		require.ErrorIs(t, err, cre.ErrRawReportTooShort)
+	})
+}
+
+// newStaticDON creates a DON with n random signers, with node operator IDs starting at 100, and their signatures on the test report.
+func newStaticDON(t *testing.T, donID uint32, f, n int) (cre.StaticDON, [][]byte) {
+	t.Helper()
+
+	hash := crypto.Keccak256(append(crypto.Keccak256(rawReport), reportContext...))
+	don := cre.StaticDON{DonID: donID, F: f, Signers: map[common.Address]uint32{}}
+	sigs := make([][]byte, n)
+	for i := range n {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		don.Signers[crypto.PubkeyToAddress(key.PublicKey)] = uint32(100 + i)
+		sigs[i], err = crypto.Sign(hash, key)
+		require.NoError(t, err)
+	}
+	return don, sigs
+}
+
+func Test_ParseReportWithConfig_BlockConfidence(t *testing.T) {
+	t.Run("registry is read at the configured block", func(t *testing.T) {
+		setupDonSettingRead(t, productionEnvironmentReplay)
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+
+		// The replay was recorded at the finalized block, so reading the latest block sends a different request.
+		env := cre.ProductionEnvironment()
+		env.BlockConfidence = cre.BlockConfidenceLatest
+		config := cre.ReportParseConfig{AcceptedEnvironments: []cre.Environment{env}}
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+
+		require.ErrorContains(t, err, "call 0: expected payload")
+	})
+
+	t.Run("unknown registry ABI version", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+
+		env := cre.ProductionEnvironment()
+		env.RegistryABIVersion = "unknown"
+		config := cre.ReportParseConfig{AcceptedEnvironments: []cre.Environment{env}}
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, reportSigs, reportContext, config)
+
+		require.ErrorContains(t, err, `unknown registry ABI version "unknown"`)
+	})
+}
@@ -0,0 +1,132 @@
+package cre
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// SigningAlgorithmECDSA is the signing algorithm of EVM reports: secp256k1 signatures over
+	// keccak256(keccak256(rawReport) || reportContext). It is used when no signing algorithm is set.
+	SigningAlgorithmECDSA = "ecdsa"
+
+	// SigningAlgorithmEd25519 is the signing algorithm of Solana reports.
+	// No verifier is registered for it by default, see NewEd25519ReportVerifier.
+	SigningAlgorithmEd25519 = "ed25519"
+)
+
+// SignerKey is a node's signing key as the capabilities registry stores it.
+// ECDSA signers are stored as their address, left-aligned. Ed25519 signers are stored as their public key.
+type SignerKey [32]byte
+
+// ECDSASignerKey returns the SignerKey of an ECDSA signer's address.
+func ECDSASignerKey(address common.Address) SignerKey {
+	var key SignerKey
+	copy(key[:], address[:])
+	return key
+}
+
+// ReportVerifier verifies report signatures made with one signing algorithm, see RegisterReportVerifier.
+type ReportVerifier interface {
+	// Digest returns the message that the DON's nodes sign.
+	Digest(rawReport, reportContext []byte) []byte
+
+	// Signer returns the key of the node that signed the digest with the signature.
+	// signers are the keys of the DON's nodes, for algorithms that cannot recover the key from the signature.
+	// The error should wrap ErrParseSignature, ErrRecoverSigner or ErrUnknownSigner.
+	Signer(digest, signature []byte, signers map[SignerKey]uint32) (SignerKey, error)
+}
+
+var reportVerifiersLock sync.RWMutex
+var reportVerifiers = map[string]ReportVerifier{
+	SigningAlgorithmECDSA: ecdsaVerifier{},
+}
+
+// RegisterReportVerifier makes a verifier available for reports signed with the algorithm, see ReportParseConfig.SigningAlgorithm.
+// It replaces any verifier already registered for the algorithm, and a nil verifier removes it.
+func RegisterReportVerifier(algorithm string, verifier ReportVerifier) {
+	reportVerifiersLock.Lock()
+	defer reportVerifiersLock.Unlock()
+	if verifier == nil {
+		delete(reportVerifiers, algorithm)
+		return
+	}
+	reportVerifiers[algorithm] = verifier
+}
+
+func reportVerifier(algorithm string) (ReportVerifier, error) {
+	if algorithm == "" {
+		algorithm = SigningAlgorithmECDSA
+	}
+
+	reportVerifiersLock.RLock()
+	defer reportVerifiersLock.RUnlock()
+	verifier, ok := reportVerifiers[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("no report verifier for signing algorithm %q", algorithm)
+	}
+	return verifier, nil
+}
+
+type ecdsaVerifier struct{}
+
+func (ecdsaVerifier) Digest(rawReport, reportContext []byte) []byte {
+	return crypto.Keccak256(append(crypto.Keccak256(rawReport), reportContext...))
+}
+
+func (ecdsaVerifier) Signer(digest, signature []byte, _ map[SignerKey]uint32) (SignerKey, error) {
+	if len(signature) != 65 {
+		return SignerKey{}, fmt.Errorf("%w: has %d bytes, expected 65", ErrParseSignature, len(signature))
+	}
+
+	sigBytes := make([]byte, len(signature))
+	copy(sigBytes, signature)
+
+	// Normalise legacy Ethereum v values (27/28 → 0/1).
+	if sigBytes[64] == 27 || sigBytes[64] == 28 {
+		sigBytes[64] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(digest, sigBytes)
+	if err != nil {
+		return SignerKey{}, fmt.Errorf("%w: %s", ErrRecoverSigner, err)
+	}
+	return ECDSASignerKey(crypto.PubkeyToAddress(*pubKey)), nil
+}
+
+// NewEd25519ReportVerifier returns an experimental verifier for ed25519 signatures over
+// sha256(sha256(rawReport) || reportContext).
+// The digest has not yet been confirmed against the signer of the consensus capability and may change,
+// so it must be registered explicitly:
+//
+//	cre.RegisterReportVerifier(cre.SigningAlgorithmEd25519, cre.NewEd25519ReportVerifier())
+func NewEd25519ReportVerifier() ReportVerifier {
+	return ed25519Verifier{}
+}
+
+type ed25519Verifier struct{}
+
+func (ed25519Verifier) Digest(rawReport, reportContext []byte) []byte {
+	inner := sha256.Sum256(rawReport)
+	outer := sha256.Sum256(append(inner[:], reportContext...))
+	return outer[:]
+}
+
+func (ed25519Verifier) Signer(digest, signature []byte, signers map[SignerKey]uint32) (SignerKey, error) {
+	if len(signature) != ed25519.SignatureSize {
+		return SignerKey{}, fmt.Errorf("%w: has %d bytes, expected %d", ErrParseSignature, len(signature), ed25519.SignatureSize)
+	}
+
+	// Ed25519 public keys cannot be recovered from signatures, so each of the DON's keys is tried.
+	for key := range signers {
+		if ed25519.Verify(key[:], digest, signature) {
+			return key, nil
+		}
+	}
+	return SignerKey{}, fmt.Errorf("%w: no signer of the DON made the signature", ErrUnknownSigner)
+}
@@ -0,0 +1,152 @@
+package cre_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Ed25519Verifier(t *testing.T) {
+	t.Run("digest", func(t *testing.T) {
+		// Computed independently of the SDK as sha256(sha256(rawReport) || reportContext).
+		expected := "2c1450c00cf30e4d6fbc48cc5b0a0becbaefac448324ac512364bca8c9632bb1"
+
+		digest := cre.NewEd25519ReportVerifier().Digest(rawReport, reportContext)
+
+		assert.Equal(t, expected, hex.EncodeToString(digest))
+	})
+
+	t.Run("valid report", func(t *testing.T) {
+		don, sigs := newEd25519StaticDON(t, reportDONID, 1, 3)
+
+		report, err := cre.VerifyReportOffline(rawReport, sigs, reportContext, don)
+
+		require.NoError(t, err)
+		require.Len(t, report.X_GeneratedCodeOnly_Unwrap().Sigs, 2)
+		assert.Equal(t, uint32(100), report.X_GeneratedCodeOnly_Unwrap().Sigs[0].SignerId)
+		assert.Equal(t, uint32(101), report.X_GeneratedCodeOnly_Unwrap().Sigs[1].SignerId)
+	})
+
+	t.Run("selected by the parse config", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		don, sigs := newEd25519StaticDON(t, reportDONID, 1, 3)
+		don.SigningAlgorithm = ""
+
+		config := cre.ReportParseConfig{StaticDONs: []cre.StaticDON{don}, SigningAlgorithm: cre.SigningAlgorithmEd25519}
+		report, err := cre.ParseReportWithConfig(runtime, rawReport, sigs, reportContext, config)
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte(reportBody), report.Body())
+	})
+
+	t.Run("signature from another DON", func(t *testing.T) {
+		don, _ := newEd25519StaticDON(t, reportDONID, 1, 3)
+		_, sigs := newEd25519StaticDON(t, reportDONID, 1, 3)
+
+		_, err := cre.VerifyReportOffline(rawReport, sigs, reportContext, don)
+
+		require.ErrorIs(t, err, cre.ErrUnknownSigner)
+	})
+
+	t.Run("duplicate signatures", func(t *testing.T) {
+		don, sigs := newEd25519StaticDON(t, reportDONID, 1, 3)
+
+		_, err := cre.VerifyReportOffline(rawReport, [][]byte{sigs[0], sigs[0]}, reportContext, don)
+
+		require.ErrorIs(t, err, cre.ErrDuplicateSigner)
+	})
+
+	t.Run("ECDSA signatures", func(t *testing.T) {
+		don, _ := newEd25519StaticDON(t, reportDONID, 1, 3)
+
+		_, err := cre.VerifyReportOffline(rawReport, reportSigs, reportContext, don)
+
+		require.ErrorIs(t, err, cre.ErrParseSignature)
+	})
+}
+
+func Test_RegisterReportVerifier(t *testing.T) {
+	t.Run("custom verifier", func(t *testing.T) {
+		cre.RegisterReportVerifier("test-algorithm", prefixVerifier{})
+		don := cre.StaticDON{
+			DonID:            reportDONID,
+			F:                1,
+			SignerKeys:       map[cre.SignerKey]uint32{{1}: 7, {2}: 8},
+			SigningAlgorithm: "test-algorithm",
+		}
+
+		report, err := cre.VerifyReportOffline(rawReport, [][]byte{{1}, {2}}, reportContext, don)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(7), report.X_GeneratedCodeOnly_Unwrap().Sigs[0].SignerId)
+		assert.Equal(t, uint32(8), report.X_GeneratedCodeOnly_Unwrap().Sigs[1].SignerId)
+	})
+
+	t.Run("ed25519 is not registered by default", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		don, sigs := newStaticDON(t, reportDONID, 1, 3)
+
+		config := cre.ReportParseConfig{StaticDONs: []cre.StaticDON{don}, SigningAlgorithm: cre.SigningAlgorithmEd25519}
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, sigs, reportContext, config)
+
+		require.ErrorContains(t, err, `no report verifier for signing algorithm "ed25519"`)
+	})
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+		don, sigs := newStaticDON(t, reportDONID, 1, 3)
+
+		config := cre.ReportParseConfig{StaticDONs: []cre.StaticDON{don}, SigningAlgorithm: "rsa"}
+		_, err := cre.ParseReportWithConfig(runtime, rawReport, sigs, reportContext, config)
+
+		require.ErrorContains(t, err, `no report verifier for signing algorithm "rsa"`)
+	})
+}
+
+// newEd25519StaticDON creates an ed25519 DON with n random signers, with node operator IDs starting at 100, and their signatures on the test report.
+func newEd25519StaticDON(t *testing.T, donID uint32, f, n int) (cre.StaticDON, [][]byte) {
+	t.Helper()
+
+	inner := sha256.Sum256(rawReport)
+	digest := sha256.Sum256(append(inner[:], reportContext...))
+	registerEd25519Verifier(t)
+	don := cre.StaticDON{DonID: donID, F: f, SignerKeys: map[cre.SignerKey]uint32{}, SigningAlgorithm: cre.SigningAlgorithmEd25519}
+	sigs := make([][]byte, n)
+	for i := range n {
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		don.SignerKeys[cre.SignerKey(public)] = uint32(100 + i)
+		sigs[i] = ed25519.Sign(private, digest[:])
+	}
+	return don, sigs
+}
+
+// registerEd25519Verifier registers the ed25519 verifier for the rest of the test.
+func registerEd25519Verifier(t *testing.T) {
+	t.Helper()
+	cre.RegisterReportVerifier(cre.SigningAlgorithmEd25519, cre.NewEd25519ReportVerifier())
+	t.Cleanup(func() { cre.RegisterReportVerifier(cre.SigningAlgorithmEd25519, nil) })
+}
+
+// prefixVerifier accepts signatures whose first byte is the first byte of a signer key.
+type prefixVerifier struct{}
+
+func (prefixVerifier) Digest(rawReport, reportContext []byte) []byte {
+	return nil
+}
+
+func (prefixVerifier) Signer(_, signature []byte, signers map[cre.SignerKey]uint32) (cre.SignerKey, error) {
+	for key := range signers {
+		if len(signature) > 0 && key[0] == signature[0] {
+			return key, nil
+		}
+	}
+	return cre.SignerKey{}, cre.ErrUnknownSigner
+}
@@ -0,0 +1,83 @@
+package cre
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	caperrors "github.com/smartcontractkit/cre-sdk-go/capabilities/errors"
+)
+
+// DefaultRetryableCodes are the capability error codes retried when RetryPolicy.RetryableCodes is empty.
+// They describe transient failures where issuing the same call again may succeed.
+var DefaultRetryableCodes = []caperrors.ErrorCode{
+	caperrors.Unavailable,
+	caperrors.DeadlineExceeded,
+	caperrors.Aborted,
+	caperrors.ResourceExhausted,
+	caperrors.ConsensusFailed,
+}
+
+// RetryPolicy describes when Retry re-issues a capability call.
+// Retry decisions are made only from the attempt count and the capability error, never from wall-clock time,
+// so every node in a DON makes the same decisions.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the call is issued, including the first one.
+	// Values below 1 are treated as 1.
+	MaxAttempts int
+
+	// RetryableCodes are the capability error codes that cause the call to be re-issued.
+	// When empty, DefaultRetryableCodes is used.
+	RetryableCodes []caperrors.ErrorCode
+}
+
+func (p RetryPolicy) isRetryable(runtime RuntimeBase, err error) bool {
+	var capErr caperrors.Error
+	if !errors.As(err, &capErr) {
+		return false
+	}
+
+	// Exceeding a CRE limit is never transient, and retrying would only use more of the budget.
+	if capErr.Code() == caperrors.LimitExceeded {
+		return false
+	}
+
+	// Private errors may differ between nodes, so only public errors can drive a retry in DON mode.
+	if _, isNode := runtime.(NodeRuntime); !isNode && capErr.Visibility() != caperrors.VisibilityPublic {
+		return false
+	}
+
+	codes := p.RetryableCodes
+	if len(codes) == 0 {
+		codes = DefaultRetryableCodes
+	}
+	return slices.Contains(codes, capErr.Code())
+}
+
+// Retry issues the capability call returned by call, and re-issues it when it fails with a retryable capability error.
+// The first call is issued immediately, retries are issued when the returned Promise is awaited.
+// Each retry is a new capability call made through the runtime, so it counts against the execution's restrictions like any other call.
+// Calls are re-issued immediately, without backoff, to keep executions deterministic.
+func Retry[T any](runtime RuntimeBase, policy RetryPolicy, call func() Promise[T]) Promise[T] {
+	first := call()
+	return NewBasicPromise(func() (T, error) {
+		maxAttempts := max(policy.MaxAttempts, 1)
+		p := first
+		for attempt := 1; ; attempt++ {
+			result, err := p.Await()
+			if err == nil || !policy.isRetryable(runtime, err) {
+				return result, err
+			}
+
+			if attempt >= maxAttempts {
+				if attempt > 1 {
+					err = fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+				}
+				return result, err
+			}
+
+			runtime.Logger().Debug("retrying capability call", "attempt", attempt+1, "maxAttempts", maxAttempts, "error", err)
+			p = call()
+		}
+	})
+}
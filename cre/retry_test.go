@@ -0,0 +1,142 @@
+package cre_test
+
+import (
+	"errors"
+	"log/slog"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caperrors "github.com/smartcontractkit/cre-sdk-go/capabilities/errors"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+type fakeRuntimeBase struct{}
+
+func (fakeRuntimeBase) CallCapability(*sdk.CapabilityRequest) cre.Promise[*sdk.CapabilityResponse] {
+	return cre.PromiseFromResult[*sdk.CapabilityResponse](nil, errors.New("not implemented"))
+}
+
+func (fakeRuntimeBase) Rand() (*rand.Rand, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (fakeRuntimeBase) Now() time.Time {
+	return time.Time{}
+}
+
+func (fakeRuntimeBase) Logger() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+type fakeNodeRuntime struct {
+	fakeRuntimeBase
+}
+
+func (fakeNodeRuntime) IsNodeRuntime() {}
+
+func publicErr(code caperrors.ErrorCode) error {
+	return caperrors.NewError(errors.New("failed"), caperrors.VisibilityPublic, caperrors.OriginSystem, code)
+}
+
+// sequencedCalls returns a call function that yields the given errors in order, then succeeds with the attempt number.
+func sequencedCalls(errs ...error) (func() cre.Promise[int], *int) {
+	attempts := 0
+	return func() cre.Promise[int] {
+		attempts++
+		if attempts <= len(errs) {
+			return cre.PromiseFromResult(0, errs[attempts-1])
+		}
+		return cre.PromiseFromResult(attempts, nil)
+	}, &attempts
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("retries retryable errors until success", func(t *testing.T) {
+		call, attempts := sequencedCalls(publicErr(caperrors.Unavailable), publicErr(caperrors.DeadlineExceeded))
+
+		result, err := cre.Retry(fakeRuntimeBase{}, cre.RetryPolicy{MaxAttempts: 3}, call).Await()
+		require.NoError(t, err)
+		assert.Equal(t, 3, result)
+		assert.Equal(t, 3, *attempts)
+	})
+
+	t.Run("first call is issued eagerly and retries lazily", func(t *testing.T) {
+		call, attempts := sequencedCalls(publicErr(caperrors.Unavailable))
+
+		p := cre.Retry(fakeRuntimeBase{}, cre.RetryPolicy{MaxAttempts: 2}, call)
+		assert.Equal(t, 1, *attempts)
+
+		_, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, 2, *attempts)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		lastErr := publicErr(caperrors.Unavailable)
+		call, attempts := sequencedCalls(publicErr(caperrors.Unavailable), lastErr, publicErr(caperrors.Unavailable))
+
+		_, err := cre.Retry(fakeRuntimeBase{}, cre.RetryPolicy{MaxAttempts: 2}, call).Await()
+		require.ErrorIs(t, err, lastErr)
+		assert.ErrorContains(t, err, "giving up after 2 attempts")
+		assert.Equal(t, 2, *attempts)
+
+		var capErr caperrors.Error
+		require.ErrorAs(t, err, &capErr)
+		assert.Equal(t, caperrors.Unavailable, capErr.Code())
+	})
+
+	t.Run("zero max attempts issues the call once", func(t *testing.T) {
+		expectedErr := publicErr(caperrors.Unavailable)
+		call, attempts := sequencedCalls(expectedErr)
+
+		_, err := cre.Retry(fakeRuntimeBase{}, cre.RetryPolicy{}, call).Await()
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 1, *attempts)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		for name, err := range map[string]error{
+			"non retryable code": publicErr(caperrors.InvalidArgument),
+			"limit exceeded":     publicErr(caperrors.LimitExceeded),
+			"plain error":        errors.New("plain"),
+			"private error":      caperrors.NewError(errors.New("failed"), caperrors.VisibilityPrivate, caperrors.OriginSystem, caperrors.Unavailable),
+		} {
+			t.Run(name, func(t *testing.T) {
+				call, attempts := sequencedCalls(err)
+
+				_, actual := cre.Retry(fakeRuntimeBase{}, cre.RetryPolicy{MaxAttempts: 3}, call).Await()
+				assert.Equal(t, err, actual)
+				assert.Equal(t, 1, *attempts)
+			})
+		}
+	})
+
+	t.Run("retries private errors in node mode", func(t *testing.T) {
+		call, attempts := sequencedCalls(caperrors.NewError(errors.New("failed"), caperrors.VisibilityPrivate, caperrors.OriginSystem, caperrors.Unavailable))
+
+		result, err := cre.Retry(fakeNodeRuntime{}, cre.RetryPolicy{MaxAttempts: 2}, call).Await()
+		require.NoError(t, err)
+		assert.Equal(t, 2, result)
+		assert.Equal(t, 2, *attempts)
+	})
+
+	t.Run("uses configured codes", func(t *testing.T) {
+		call, attempts := sequencedCalls(publicErr(caperrors.NotFound))
+
+		policy := cre.RetryPolicy{MaxAttempts: 2, RetryableCodes: []caperrors.ErrorCode{caperrors.NotFound}}
+		result, err := cre.Retry(fakeRuntimeBase{}, policy, call).Await()
+		require.NoError(t, err)
+		assert.Equal(t, 2, result)
+		assert.Equal(t, 2, *attempts)
+
+		call, attempts = sequencedCalls(publicErr(caperrors.Unavailable))
+		_, err = cre.Retry(fakeRuntimeBase{}, policy, call).Await()
+		require.Error(t, err)
+		assert.Equal(t, 1, *attempts)
+	})
+}
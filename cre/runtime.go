@@ -10,6 +10,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
 	"github.com/smartcontractkit/chainlink-protos/cre/go/values"
+	"google.golang.org/protobuf/proto"
 )
 
 type SecretRequest = sdk.SecretRequest
@@ -45,7 +46,7 @@ type SecretsProvider interface {
 	GetSecret(*SecretRequest) Promise[*Secret]
 
 	// GetSecrets retrieves multiple secrets in a single batch call.
-	// Returns an error if any secret in the batch fails.
+	// Returns an error if any secret in the batch fails, a *SecretsError when the runtime reports which ones did.
 	GetSecrets([]*SecretRequest) Promise[[]*Secret]
 }
 
@@ -103,6 +104,7 @@ type Runtime interface {
 // - ConsensusCommonPrefixAggregation
 // - ConsensusCommonSuffixAggregation
 // - ConsensusAggregationFromTags
+// - Fields
 // By using this interface with capability SDKs or RunInNodeMode, you are assured that all aggregated values are Byzantine fault-tolerant.
 type ConsensusAggregation[T any] interface {
 	// Descriptor is meant to be used by the Runtime
@@ -120,6 +122,10 @@ type ConsensusAggregation[T any] interface {
 	// WithDefault returns a new ConsensusAggregation with the given default value
 	// If consensus cannot be reached, the default value will be used if it is not nil instead of returning an error.
 	WithDefault(t T) ConsensusAggregation[T]
+
+	// WithValidator returns a new ConsensusAggregation that also validates each node's observation with validator before consensus.
+	// Validators added to the same aggregation are all applied, in the order they were added.
+	WithValidator(validator func(T) error) ConsensusAggregation[T]
 }
 
 type consensusDescriptor[T any] sdk.ConsensusDescriptor
@@ -143,6 +149,10 @@ func (c *consensusDescriptor[T]) WithDefault(t T) ConsensusAggregation[T] {
 	}
 }
 
+func (c *consensusDescriptor[T]) WithValidator(validator func(T) error) ConsensusAggregation[T] {
+	return withValidator[T](c, validator)
+}
+
 var _ ConsensusAggregation[int] = (*consensusDescriptor[int])(nil)
 
 type consensusWithDefault[T any] struct {
@@ -170,6 +180,55 @@ func (c *consensusWithDefault[T]) WithDefault(t T) ConsensusAggregation[T] {
 	}
 }
 
+func (c *consensusWithDefault[T]) WithValidator(validator func(T) error) ConsensusAggregation[T] {
+	return withValidator[T](c, validator)
+}
+
+// ObservationValidator can be implemented by a ConsensusAggregation to validate each node's observation before consensus.
+// An invalid observation is reported to consensus as an error, so the node counts as faulty instead of skewing the aggregated value.
+// The default value is not validated.
+type ObservationValidator[T any] interface {
+	Validate(t T) error
+}
+
+// withValidator implements ConsensusAggregation.WithValidator for the aggregations in this package.
+func withValidator[T any](ca ConsensusAggregation[T], validator func(T) error) ConsensusAggregation[T] {
+	if validator == nil || ca.Err() != nil {
+		return ca
+	}
+	return &consensusWithValidator[T]{ConsensusAggregation: ca, validator: validator}
+}
+
+type consensusWithValidator[T any] struct {
+	ConsensusAggregation[T]
+	validator func(T) error
+}
+
+var _ ObservationValidator[int] = (*consensusWithValidator[int])(nil)
+
+func (c *consensusWithValidator[T]) Validate(t T) error {
+	if err := validateObservation(c.ConsensusAggregation, t); err != nil {
+		return err
+	}
+	return c.validator(t)
+}
+
+func (c *consensusWithValidator[T]) WithDefault(t T) ConsensusAggregation[T] {
+	return &consensusWithValidator[T]{ConsensusAggregation: c.ConsensusAggregation.WithDefault(t), validator: c.validator}
+}
+
+func (c *consensusWithValidator[T]) WithValidator(validator func(T) error) ConsensusAggregation[T] {
+	return withValidator[T](c, validator)
+}
+
+// validateObservation validates the observation if the aggregation implements ObservationValidator.
+func validateObservation[T any](ca ConsensusAggregation[T], t T) error {
+	if v, ok := ca.(ObservationValidator[T]); ok {
+		return v.Validate(t)
+	}
+	return nil
+}
+
 type consensusDescriptorError[T any] struct {
 	Error error
 }
@@ -190,6 +249,10 @@ func (d *consensusDescriptorError[T]) WithDefault(_ T) ConsensusAggregation[T] {
 	return d
 }
 
+func (d *consensusDescriptorError[T]) WithValidator(_ func(T) error) ConsensusAggregation[T] {
+	return d
+}
+
 var nodeModeCallInDonMode = errors.New("cannot use NodeRuntime outside RunInNodeMode")
 
 func NodeModeCallInDonMode() error {
@@ -217,7 +280,11 @@ func RunInNodeMode[C, T any](
 		descriptor := ca.Descriptor()
 		var err error
 		if d := ca.Default(); d != nil {
-			defaultValue, err = values.Wrap(d)
+			if m, ok := any(*d).(proto.Message); ok {
+				defaultValue, err = wrapProtoObservation(m, descriptor)
+			} else {
+				defaultValue, err = values.Wrap(d)
+			}
 			if err != nil {
 				return &sdk.SimpleConsensusInputs{Observation: &sdk.SimpleConsensusInputs_Error{Error: err.Error()}}
 			}
@@ -235,7 +302,17 @@ func RunInNodeMode[C, T any](
 			return returnValue
 		}
 
-		wrapped, err := values.Wrap(result)
+		if err = validateObservation(ca, result); err != nil {
+			returnValue.Observation = &sdk.SimpleConsensusInputs_Error{Error: fmt.Sprintf("invalid observation: %v", err)}
+			return returnValue
+		}
+
+		var wrapped values.Value
+		if m, ok := any(result).(proto.Message); ok {
+			wrapped, err = wrapProtoObservation(m, descriptor)
+		} else {
+			wrapped, err = values.Wrap(result)
+		}
 		if err != nil {
 			returnValue.Observation = &sdk.SimpleConsensusInputs_Error{Error: err.Error()}
 			return returnValue
@@ -254,6 +331,15 @@ func RunInNodeMode[C, T any](
 		if typ == nil {
 			return t, fmt.Errorf("RunInNodeMode requires a concrete type for T, got nil reflect.Type")
 		}
+
+		if m, ok := any(t).(proto.Message); ok {
+			unwrapped, err := unwrapProtoObservation(m, v, ca.Descriptor())
+			if err != nil {
+				return t, err
+			}
+			return unwrapped.(T), nil
+		}
+
 		// If T is a pointer type, we need to allocate the underlying type and pass its pointer to UnwrapTo
 		if typ.Kind() == reflect.Ptr {
 			elem := reflect.New(typ.Elem())
@@ -2,6 +2,7 @@ package cre
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"math/rand"
@@ -13,6 +14,11 @@ import (
 	valuespb "github.com/smartcontractkit/chainlink-protos/cre/go/values/pb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+	"google.golang.org/protobuf/types/known/typepb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestRunInNodeMode_SimpleConsensusType(t *testing.T) {
@@ -136,6 +142,123 @@ func TestRunInNodeMode_ClearsIgnoredFields(t *testing.T) {
 	assert.Equal(t, "", val.Nested.NestedIgnored)
 }
 
+func TestRunInNodeMode_ProtoMessage(t *testing.T) {
+	runtime := &mockRuntime{}
+	observed := &apipb.Api{
+		Name:          "api",
+		Version:       "v1",
+		Methods:       []*apipb.Method{{Name: "method", Syntax: typepb.Syntax_SYNTAX_PROTO3}},
+		SourceContext: &sourcecontextpb.SourceContext{FileName: "file.proto"},
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		p := RunInNodeMode("", runtime, func(_ string, nr NodeRuntime) (*apipb.Api, error) {
+			return observed, nil
+		}, ConsensusIdenticalAggregation[*apipb.Api]())
+
+		val, err := p.Await()
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(observed, val))
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		aggregation := Fields[*apipb.Api]().
+			Identical("name", "methods", "source_context").
+			Ignore("version", "options", "mixins", "syntax", "edition")
+
+		p := RunInNodeMode("", runtime, func(_ string, nr NodeRuntime) (*apipb.Api, error) {
+			return observed, nil
+		}, aggregation)
+
+		val, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, "api", val.Name)
+		assert.Empty(t, val.Version)
+		assert.True(t, proto.Equal(observed.SourceContext, val.SourceContext))
+		require.Len(t, val.Methods, 1)
+		assert.Equal(t, typepb.Syntax_SYNTAX_PROTO3, val.Methods[0].Syntax)
+	})
+
+	t.Run("default", func(t *testing.T) {
+		p := RunInNodeMode("", runtime, func(_ string, nr NodeRuntime) (*wrapperspb.UInt64Value, error) {
+			return nil, errors.New("error")
+		}, Fields[*wrapperspb.UInt64Value]().Median("value").WithDefault(wrapperspb.UInt64(7)))
+
+		val, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), val.GetValue())
+	})
+}
+
+func TestRunInNodeMode_Validator(t *testing.T) {
+	runtime := &mockRuntime{}
+	positive := func(i int) error {
+		if i <= 0 {
+			return errors.New("must be positive")
+		}
+		return nil
+	}
+
+	t.Run("invalid observation is an error", func(t *testing.T) {
+		p := RunInNodeMode("", runtime, func(_ string, nr NodeRuntime) (int, error) {
+			return 0, nil
+		}, ConsensusMedianAggregation[int]().WithValidator(positive))
+
+		_, err := p.Await()
+		require.Error(t, err)
+		assert.Equal(t, "invalid observation: must be positive", err.Error())
+	})
+
+	t.Run("valid observation is used", func(t *testing.T) {
+		p := RunInNodeMode("", runtime, func(_ string, nr NodeRuntime) (int, error) {
+			return 42, nil
+		}, ConsensusMedianAggregation[int]().WithValidator(positive))
+
+		val, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, 42, val)
+	})
+
+	t.Run("default is used for invalid observation", func(t *testing.T) {
+		p := RunInNodeMode("", runtime, func(_ string, nr NodeRuntime) (int, error) {
+			return -1, nil
+		}, ConsensusMedianAggregation[int]().WithValidator(positive).WithDefault(100))
+
+		val, err := p.Await()
+		require.NoError(t, err)
+		assert.Equal(t, 100, val)
+	})
+}
+
+func TestConsensusAggregation_WithValidator(t *testing.T) {
+	lessThan := func(limit int) func(int) error {
+		return func(i int) error {
+			if i >= limit {
+				return fmt.Errorf("must be less than %d", limit)
+			}
+			return nil
+		}
+	}
+
+	ca := ConsensusMedianAggregation[int]().WithValidator(lessThan(10)).WithDefault(5).WithValidator(lessThan(3))
+	require.NoError(t, ca.Err())
+	require.NotNil(t, ca.Default())
+	assert.Equal(t, 5, *ca.Default())
+	assert.Equal(t, sdk.AggregationType_AGGREGATION_TYPE_MEDIAN, ca.Descriptor().GetAggregation())
+	assert.NoError(t, validateObservation(ca, 2))
+	assert.EqualError(t, validateObservation(ca, 5), "must be less than 3")
+	assert.EqualError(t, validateObservation(ca, 10), "must be less than 10")
+
+	assert.NoError(t, validateObservation(ConsensusMedianAggregation[int]().WithValidator(nil), 100))
+
+	invalid := ConsensusIdenticalAggregation[chan int]().WithValidator(func(chan int) error { return errors.New("unused") })
+	assert.Error(t, invalid.Err())
+	assert.NoError(t, validateObservation(invalid, nil))
+
+	custom := &medianTestFieldDescription[int]{}
+	assert.NoError(t, validateObservation[int](custom, 100), "aggregations without a validator accept any observation")
+}
+
 // mockNodeRuntime implements NodeRuntime for testing.
 type mockNodeRuntime struct{}
 
@@ -236,6 +359,10 @@ func (h *medianTestFieldDescription[T]) WithDefault(t T) ConsensusAggregation[T]
 	return &medianTestFieldDescription[T]{T: t}
 }
 
+func (h *medianTestFieldDescription[T]) WithValidator(_ func(T) error) ConsensusAggregation[T] {
+	return h
+}
+
 func reportFromValue(result *valuespb.Value) *valuespb.Value {
 	return &valuespb.Value{
 		Value: result.Value,
@@ -0,0 +1,180 @@
+package cre
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+)
+
+// SecretsError is returned by SecretsProvider.GetSecrets when some of the secrets could not be retrieved.
+type SecretsError struct {
+	// Secrets are the secrets that were retrieved, in the order they were requested.
+	Secrets []*Secret
+	// Failures describe the secrets that could not be retrieved.
+	Failures []*sdk.SecretError
+}
+
+func (e *SecretsError) Error() string {
+	errs := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = fmt.Sprintf("%s: %s", failure.Id, failure.Error)
+	}
+	return "error(s) getting secrets: " + strings.Join(errs, "; ")
+}
+
+// LoadSecrets fills the string fields of a struct of type T that are tagged with `secret:"namespace/id"` in one GetSecrets call.
+// The namespace defaults to DefaultSecretNamespace when the tag only has an id. Fields tagged with `secret:"namespace/id,optional"`
+// are left empty when their secret cannot be retrieved; every other missing secret is reported in the returned error.
+func LoadSecrets[T any](sp SecretsProvider) Promise[T] {
+	var zero T
+	fields, err := secretFieldsOf(reflect.TypeOf(zero))
+	if err != nil {
+		return PromiseFromResult(zero, err)
+	}
+
+	requests := make([]*SecretRequest, 0, len(fields))
+	seen := map[secretKey]bool{}
+	for _, field := range fields {
+		if seen[field.key] {
+			continue
+		}
+		seen[field.key] = true
+		requests = append(requests, &SecretRequest{Namespace: field.key.namespace, Id: field.key.id})
+	}
+
+	return Then(loadSecrets(sp, requests), func(loaded *SecretsError) (T, error) {
+		values := make(map[secretKey]string, len(loaded.Secrets))
+		for _, secret := range loaded.Secrets {
+			values[requestedKey(requests, secret.Namespace, secret.Id)] = secret.Value
+		}
+		failures := make(map[secretKey]string, len(loaded.Failures))
+		for _, failure := range loaded.Failures {
+			failures[requestedKey(requests, failure.Namespace, failure.Id)] = failure.Error
+		}
+
+		var result T
+		target := reflect.ValueOf(&result).Elem()
+		var errs []error
+		for _, field := range fields {
+			value, ok := values[field.key]
+			switch {
+			case ok:
+				target.FieldByIndex(field.index).SetString(value)
+			case field.optional:
+			default:
+				reason, failed := failures[field.key]
+				if !failed {
+					reason = "not returned"
+				}
+				errs = append(errs, fmt.Errorf("secret %s/%s for field %s: %s", field.key.namespace, field.key.id, field.name, reason))
+			}
+		}
+
+		if len(errs) > 0 {
+			return zero, fmt.Errorf("failed to load secrets into %T: %w", zero, errors.Join(errs...))
+		}
+		return result, nil
+	})
+}
+
+// loadSecrets gets the secrets, treating a SecretsError as a partial result.
+func loadSecrets(sp SecretsProvider, requests []*SecretRequest) Promise[*SecretsError] {
+	if len(requests) == 0 {
+		return PromiseFromResult(&SecretsError{}, nil)
+	}
+
+	pending := sp.GetSecrets(requests)
+	return NewBasicPromise(func() (*SecretsError, error) {
+		secrets, err := pending.Await()
+		if err == nil {
+			return &SecretsError{Secrets: secrets}, nil
+		}
+
+		var secretsErr *SecretsError
+		if errors.As(err, &secretsErr) {
+			return secretsErr, nil
+		}
+		return nil, err
+	})
+}
+
+type secretKey struct {
+	namespace string
+	id        string
+}
+
+// requestedKey returns the key of the request that a secret or failure returned by the host answers.
+// The host may omit the namespace, in which case the secret answers the only request with its id,
+// or the request in DefaultSecretNamespace when several namespaces have a secret with that id.
+func requestedKey(requests []*SecretRequest, namespace, id string) secretKey {
+	if namespace != "" {
+		return secretKey{namespace: namespace, id: id}
+	}
+
+	var match *SecretRequest
+	for _, request := range requests {
+		if request.Id != id {
+			continue
+		}
+		if match != nil {
+			return secretKey{namespace: DefaultSecretNamespace, id: id}
+		}
+		match = request
+	}
+	if match == nil {
+		return secretKey{namespace: DefaultSecretNamespace, id: id}
+	}
+	return secretKey{namespace: match.Namespace, id: id}
+}
+
+type secretField struct {
+	key      secretKey
+	optional bool
+	name     string
+	index    []int
+}
+
+func secretFieldsOf(t reflect.Type) ([]secretField, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("LoadSecrets requires a struct type, got %v", t)
+	}
+
+	var fields []secretField
+	for _, field := range reflect.VisibleFields(t) {
+		tag, ok := field.Tag.Lookup("secret")
+		if !ok {
+			continue
+		}
+
+		if !field.IsExported() {
+			return nil, fmt.Errorf("secret field %s of %v must be exported", field.Name, t)
+		}
+		if field.Type.Kind() != reflect.String {
+			return nil, fmt.Errorf("secret field %s of %v must be a string, got %v", field.Name, t, field.Type)
+		}
+
+		name, option, _ := strings.Cut(tag, ",")
+		if option != "" && option != "optional" {
+			return nil, fmt.Errorf("secret field %s of %v has unknown option %q", field.Name, t, option)
+		}
+
+		namespace, id, found := strings.Cut(name, "/")
+		if !found {
+			namespace, id = DefaultSecretNamespace, name
+		}
+		if namespace == "" || id == "" {
+			return nil, fmt.Errorf("secret field %s of %v has invalid tag %q, expected \"namespace/id\"", field.Name, t, tag)
+		}
+
+		fields = append(fields, secretField{
+			key:      secretKey{namespace: namespace, id: id},
+			optional: option == "optional",
+			name:     field.Name,
+			index:    field.Index,
+		})
+	}
+	return fields, nil
+}
@@ -0,0 +1,123 @@
+package cre_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type apiSecrets struct {
+	APIKey   string `secret:"api_key"`
+	Password string `secret:"db/password"`
+	Token    string `secret:"db/token,optional"`
+	Ignored  string
+}
+
+func TestLoadSecrets(t *testing.T) {
+	t.Run("fills tagged fields", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{
+			"main": {"api_key": "key"},
+			"db":   {"password": "pass", "token": "token"},
+		})
+
+		secrets, err := cre.LoadSecrets[apiSecrets](runtime).Await()
+
+		require.NoError(t, err)
+		assert.Equal(t, apiSecrets{APIKey: "key", Password: "pass", Token: "token"}, secrets)
+	})
+
+	t.Run("optional fields may be missing", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{
+			"main": {"api_key": "key"},
+			"db":   {"password": "pass"},
+		})
+
+		secrets, err := cre.LoadSecrets[apiSecrets](runtime).Await()
+
+		require.NoError(t, err)
+		assert.Equal(t, apiSecrets{APIKey: "key", Password: "pass"}, secrets)
+	})
+
+	t.Run("reports every missing required field", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+
+		_, err := cre.LoadSecrets[apiSecrets](runtime).Await()
+
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to load secrets into cre_test.apiSecrets")
+		assert.ErrorContains(t, err, "secret main/api_key for field APIKey: could not find secret main")
+		assert.ErrorContains(t, err, "secret db/password for field Password: could not find secret db")
+		assert.NotContains(t, err.Error(), "Token")
+	})
+
+	t.Run("responses without a namespace fill the requested fields", func(t *testing.T) {
+		provider := &fixedSecretsProvider{secrets: []*cre.Secret{
+			{Id: "api_key", Value: "key"},
+			{Id: "password", Value: "pass"},
+			{Namespace: "db", Id: "token", Value: "token"},
+		}}
+
+		secrets, err := cre.LoadSecrets[apiSecrets](provider).Await()
+
+		require.NoError(t, err)
+		assert.Equal(t, apiSecrets{APIKey: "key", Password: "pass", Token: "token"}, secrets)
+	})
+
+	t.Run("other errors are returned", func(t *testing.T) {
+		expected := errors.New("host unavailable")
+
+		_, err := cre.LoadSecrets[apiSecrets](&failingSecretsProvider{err: expected}).Await()
+
+		require.ErrorIs(t, err, expected)
+	})
+
+	t.Run("invalid types", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{})
+
+		_, err := cre.LoadSecrets[string](runtime).Await()
+		assert.ErrorContains(t, err, "LoadSecrets requires a struct type, got string")
+
+		_, err = cre.LoadSecrets[struct {
+			Count int `secret:"count"`
+		}](runtime).Await()
+		assert.ErrorContains(t, err, "secret field Count of struct { Count int \"secret:\\\"count\\\"\" } must be a string, got int")
+
+		_, err = cre.LoadSecrets[struct {
+			Key string `secret:"/key"`
+		}](runtime).Await()
+		assert.ErrorContains(t, err, `has invalid tag "/key", expected "namespace/id"`)
+
+		_, err = cre.LoadSecrets[struct {
+			Key string `secret:"key,required"`
+		}](runtime).Await()
+		assert.ErrorContains(t, err, `has unknown option "required"`)
+	})
+}
+
+type failingSecretsProvider struct {
+	err error
+}
+
+func (f *failingSecretsProvider) GetSecret(*cre.SecretRequest) cre.Promise[*cre.Secret] {
+	return cre.PromiseFromResult[*cre.Secret](nil, f.err)
+}
+
+func (f *failingSecretsProvider) GetSecrets([]*cre.SecretRequest) cre.Promise[[]*cre.Secret] {
+	return cre.PromiseFromResult[[]*cre.Secret](nil, f.err)
+}
+
+type fixedSecretsProvider struct {
+	secrets []*cre.Secret
+}
+
+func (f *fixedSecretsProvider) GetSecret(*cre.SecretRequest) cre.Promise[*cre.Secret] {
+	return cre.PromiseFromResult[*cre.Secret](nil, errors.New("not implemented"))
+}
+
+func (f *fixedSecretsProvider) GetSecrets([]*cre.SecretRequest) cre.Promise[[]*cre.Secret] {
+	return cre.PromiseFromResult(f.secrets, nil)
+}
@@ -0,0 +1,26 @@
+package testutils
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+)
+
+// AssertAggregationLayout fails the test if the layout of the ConsensusAggregation differs from the expected lines.
+// The layout is rendered by cre.DescribeAggregation, with one line per field, for example "Price as price: median".
+// Asserting the layout catches renamed fields or changed mapstructure tags that would silently change consensus.
+func AssertAggregationLayout[T any](tb testing.TB, ca cre.ConsensusAggregation[T], expected ...string) {
+	tb.Helper()
+
+	description, err := cre.DescribeAggregation(ca)
+	if err != nil {
+		tb.Errorf("cannot describe aggregation: %v", err)
+		return
+	}
+
+	if actual := description.Layout(); !slices.Equal(expected, actual) {
+		tb.Errorf("unexpected aggregation layout\nexpected:\n\t%s\nactual:\n\t%s", strings.Join(expected, "\n\t"), strings.Join(actual, "\n\t"))
+	}
+}
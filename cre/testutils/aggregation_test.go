@@ -0,0 +1,49 @@
+package testutils_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+)
+
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertAggregationLayout(t *testing.T) {
+	type price struct {
+		Price  int64  `consensus_aggregation:"median" mapstructure:"price"`
+		Symbol string `consensus_aggregation:"identical"`
+		Debug  string `consensus_aggregation:"ignore"`
+	}
+	aggregation := cre.ConsensusAggregationFromTags[price]().WithDefault(price{Price: 1})
+
+	t.Run("matching layout", func(t *testing.T) {
+		testutils.AssertAggregationLayout(t, aggregation, "Price as price: median", "Symbol: identical", "Debug: ignored")
+	})
+
+	t.Run("changed layout", func(t *testing.T) {
+		tb := &recordingTB{TB: t}
+		testutils.AssertAggregationLayout(tb, aggregation, "Price: median", "Symbol: identical", "Debug: ignored")
+		assert.Len(t, tb.errors, 1)
+		assert.Contains(t, tb.errors[0], "Price as price: median")
+	})
+
+	t.Run("invalid aggregation", func(t *testing.T) {
+		tb := &recordingTB{TB: t}
+		testutils.AssertAggregationLayout(tb, cre.ConsensusIdenticalAggregation[chan int]())
+		assert.Len(t, tb.errors, 1)
+		assert.Contains(t, tb.errors[0], "cannot describe aggregation")
+	})
+}
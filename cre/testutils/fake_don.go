@@ -0,0 +1,373 @@
+package testutils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils/registry"
+	"github.com/smartcontractkit/cre-sdk-go/internal/capreg"
+	consensusmock "github.com/smartcontractkit/cre-sdk-go/internal_testing/capabilities/consensus/mock"
+)
+
+// FakeDONID is the ID of the DON created by NewFakeDON.
+const FakeDONID = uint32(1)
+
+// FakeDON is a workflow DON with generated signing keys.
+// Reports generated by the runtime are signed by the DON, and the capabilities registry in cre.ProductionEnvironment
+// returns the DON's signers, so reports can be parsed and verified end-to-end with cre.ParseReport.
+// It must be created with NewFakeDON.
+type FakeDON struct {
+	// WorkflowID, WorkflowName and WorkflowOwner are written to the headers of generated reports.
+	// WorkflowName is the name as it is stored on chain, see cre.WorkflowNameHash.
+	WorkflowID    [32]byte
+	WorkflowName  [10]byte
+	WorkflowOwner common.Address
+
+	// ConfigVersion is the DON config version written to the headers of generated reports.
+	ConfigVersion uint32
+
+	// TimeProvider returns the timestamp of generated reports. It defaults to time.Now.
+	TimeProvider func() time.Time
+
+	tb          testing.TB
+	f           int
+	keys        []*ecdsa.PrivateKey
+	p2pIDs      [][32]byte
+	operatorIDs []uint32
+	registryABI abi.ABI
+
+	lock  sync.Mutex
+	seqNr uint64
+}
+
+// NewFakeDON creates a DON with n nodes, which tolerates f faulty nodes, and registers it with the test.
+// It registers an EVM capability for cre.ProductionEnvironment's chain that answers the capabilities registry's
+// getDON and getNodesByP2PIds calls, and a consensus capability that signs reports with f+1 of the DON's keys.
+// If a consensus capability is already registered, for example by NewRuntime, its Report method is replaced.
+func NewFakeDON(tb testing.TB, n, f int) *FakeDON {
+	tb.Helper()
+
+	if f < 0 || n < f+1 {
+		tb.Fatalf("a DON with %d nodes cannot sign reports with f=%d, it needs at least f+1 nodes", n, f)
+		return nil
+	}
+
+	registryABI, err := abi.JSON(strings.NewReader(capreg.V2ABI))
+	if err != nil {
+		tb.Fatalf("cannot parse the capabilities registry ABI: %v", err)
+		return nil
+	}
+
+	don := &FakeDON{
+		WorkflowName:  cre.WorkflowNameHash("fake-workflow"),
+		WorkflowOwner: common.HexToAddress("0x00000000000000000000000000000000000000ff"),
+		ConfigVersion: 1,
+		TimeProvider:  time.Now,
+		tb:            tb,
+		f:             f,
+		registryABI:   registryABI,
+	}
+	for i := range n {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			tb.Fatalf("cannot generate key for node %d: %v", i, err)
+			return nil
+		}
+
+		var p2pID [32]byte
+		binary.BigEndian.PutUint32(p2pID[28:], uint32(i+1))
+		don.keys = append(don.keys, key)
+		don.p2pIDs = append(don.p2pIDs, p2pID)
+		don.operatorIDs = append(don.operatorIDs, uint32(i+1))
+	}
+
+	reg := registry.GetRegistry(tb)
+	if err = reg.RegisterCapability(&fakeRegistryCapability{don: don, env: cre.ProductionEnvironment()}); err != nil {
+		tb.Fatalf("cannot register the fake DON's EVM capability: %v", err)
+		return nil
+	}
+
+	consensus, err := consensusmock.NewConsensusCapability(tb)
+	if err == nil {
+		consensus.Simple = defaultSimpleConsensus
+	} else {
+		existing, _ := reg.GetCapability(consensus.ID())
+		var ok bool
+		if consensus, ok = existing.(*consensusmock.ConsensusCapability); !ok {
+			tb.Fatalf("cannot sign reports with the fake DON, the consensus capability is a %T", existing)
+			return nil
+		}
+	}
+	consensus.Report = don.report
+
+	// Signers are cached by DON ID, so the signers of another test's fake DON must not be used.
+	cre.InvalidateDONCache(cre.ProductionEnvironment(), FakeDONID)
+	tb.Cleanup(func() {
+		cre.InvalidateDONCache(cre.ProductionEnvironment(), FakeDONID)
+	})
+
+	return don
+}
+
+// F returns the number of faulty nodes the DON tolerates. Reports are signed by F+1 nodes.
+func (d *FakeDON) F() int {
+	return d.f
+}
+
+// Signers returns the address of each node's signing key, in node order.
+func (d *FakeDON) Signers() []common.Address {
+	signers := make([]common.Address, len(d.keys))
+	for i, key := range d.keys {
+		signers[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+	return signers
+}
+
+// StaticDON returns the DON's signers as a cre.StaticDON, to verify reports without reading from the chain.
+func (d *FakeDON) StaticDON() cre.StaticDON {
+	signers := make(map[common.Address]uint32, len(d.keys))
+	for i, signer := range d.Signers() {
+		signers[signer] = d.operatorIDs[i]
+	}
+	return cre.StaticDON{DonID: FakeDONID, F: d.f, Signers: signers}
+}
+
+// Sign signs a raw report and report context with F+1 nodes of the DON.
+func (d *FakeDON) Sign(rawReport, reportContext []byte) []*sdk.AttributedSignature {
+	hash := crypto.Keccak256(append(crypto.Keccak256(rawReport), reportContext...))
+	sigs := make([]*sdk.AttributedSignature, d.f+1)
+	for i := range sigs {
+		sig, err := crypto.Sign(hash, d.keys[i])
+		if err != nil {
+			d.tb.Fatalf("cannot sign report with node %d: %v", i, err)
+			return nil
+		}
+		sigs[i] = &sdk.AttributedSignature{Signature: sig, SignerId: d.operatorIDs[i]}
+	}
+	return sigs
+}
+
+func (d *FakeDON) report(_ context.Context, input *sdk.ReportRequest) (*sdk.ReportResponse, error) {
+	d.lock.Lock()
+	d.seqNr++
+	seqNr := d.seqNr
+	d.lock.Unlock()
+
+	header := cre.ReportHeader{
+		Version:          1,
+		Timestamp:        d.TimeProvider(),
+		DONID:            FakeDONID,
+		DONConfigVersion: d.ConfigVersion,
+		WorkflowID:       d.WorkflowID,
+		WorkflowName:     d.WorkflowName,
+		WorkflowOwner:    d.WorkflowOwner,
+		ReportID:         [2]byte{0, 1},
+	}
+	copy(header.ExecutionID[:], crypto.Keccak256([]byte("execution"), binary.BigEndian.AppendUint64(nil, seqNr)))
+
+	rawReport, err := header.Encode()
+	if err != nil {
+		return nil, err
+	}
+	rawReport = append(rawReport, input.EncodedPayload...)
+
+	// The report context is the config digest, followed by the sequence number, padded to three words.
+	configDigest := crypto.Keccak256([]byte("fake DON config"), binary.BigEndian.AppendUint32(nil, d.ConfigVersion))
+	reportContext := make([]byte, 96)
+	copy(reportContext, configDigest)
+	binary.BigEndian.PutUint64(reportContext[32:40], seqNr)
+
+	return &sdk.ReportResponse{
+		ConfigDigest:  configDigest,
+		SeqNr:         seqNr,
+		ReportContext: reportContext,
+		RawReport:     rawReport,
+		Sigs:          d.Sign(rawReport, reportContext),
+	}, nil
+}
+
+// fakeRegistryCapability is an EVM capability that answers calls to the capabilities registry with a FakeDON.
+// The EVM capability's mock cannot be used, as its module depends on this one.
+type fakeRegistryCapability struct {
+	don *FakeDON
+	env cre.Environment
+}
+
+func (c *fakeRegistryCapability) ID() string {
+	return "evm:ChainSelector:" + strconv.FormatUint(c.env.ChainSelector, 10) + "@1.0.0"
+}
+
+func (c *fakeRegistryCapability) Invoke(_ context.Context, request *sdk.CapabilityRequest) *sdk.CapabilityResponse {
+	data, err := c.call(request)
+	if err != nil {
+		return &sdk.CapabilityResponse{Response: &sdk.CapabilityResponse_Error{Error: err.Error()}}
+	}
+
+	var reply []byte
+	reply = protowire.AppendTag(reply, 1, protowire.BytesType)
+	reply = protowire.AppendBytes(reply, data)
+	return &sdk.CapabilityResponse{Response: &sdk.CapabilityResponse_Payload{Payload: &anypb.Any{
+		TypeUrl: "type.googleapis.com/capabilities.blockchain.evm.v1alpha.CallContractReply",
+		Value:   reply,
+	}}}
+}
+
+func (c *fakeRegistryCapability) call(request *sdk.CapabilityRequest) ([]byte, error) {
+	if request.Method != "CallContract" {
+		return nil, fmt.Errorf("the fake DON's EVM capability does not support %s", request.Method)
+	}
+
+	to, data, err := decodeCallContractRequest(request.GetPayload().GetValue())
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(common.BytesToAddress(to).Hex(), c.env.RegistryAddress) {
+		return nil, fmt.Errorf("the fake DON's EVM capability only answers calls to the capabilities registry, not %x", to)
+	}
+
+	if len(data) < 4 {
+		return nil, errors.New("call data is too short to contain a method ID")
+	}
+
+	method, err := c.don.registryABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s call: %w", method.Name, err)
+	}
+
+	switch method.Name {
+	case "getDON":
+		return c.getDON(method, args[0].(uint32))
+	case "getNodesByP2PIds":
+		return c.getNodesByP2PIds(method, args[0].([][32]byte))
+	default:
+		return nil, fmt.Errorf("the fake DON does not support %s", method.Name)
+	}
+}
+
+type fakeDONInfo struct {
+	Id                       uint32
+	ConfigCount              uint32
+	F                        uint8
+	IsPublic                 bool
+	AcceptsWorkflows         bool
+	NodeP2PIds               [][32]byte
+	DonFamilies              []string
+	Name                     string
+	Config                   []byte
+	CapabilityConfigurations []struct {
+		CapabilityId string
+		Config       []byte
+	}
+}
+
+type fakeNodeInfo struct {
+	NodeOperatorId      uint32
+	ConfigCount         uint32
+	WorkflowDONId       uint32
+	Signer              [32]byte
+	P2pId               [32]byte
+	EncryptionPublicKey [32]byte
+	CsaKey              [32]byte
+	CapabilityIds       []string
+	CapabilitiesDONIds  []*big.Int
+}
+
+func (c *fakeRegistryCapability) getDON(method *abi.Method, donID uint32) ([]byte, error) {
+	if donID != FakeDONID {
+		return nil, fmt.Errorf("DON %d does not exist", donID)
+	}
+
+	return method.Outputs.Pack(fakeDONInfo{
+		Id:               FakeDONID,
+		ConfigCount:      c.don.ConfigVersion,
+		F:                uint8(c.don.f),
+		AcceptsWorkflows: true,
+		NodeP2PIds:       c.don.p2pIDs,
+		Name:             "fake DON",
+	})
+}
+
+func (c *fakeRegistryCapability) getNodesByP2PIds(method *abi.Method, p2pIDs [][32]byte) ([]byte, error) {
+	signers := c.don.Signers()
+	nodes := make([]fakeNodeInfo, 0, len(p2pIDs))
+	for _, p2pID := range p2pIDs {
+		i := int(binary.BigEndian.Uint32(p2pID[28:])) - 1
+		if i < 0 || i >= len(signers) || p2pID != c.don.p2pIDs[i] {
+			return nil, fmt.Errorf("node %x does not exist", p2pID)
+		}
+
+		node := fakeNodeInfo{NodeOperatorId: c.don.operatorIDs[i], ConfigCount: 1, WorkflowDONId: FakeDONID, P2pId: p2pID}
+		// The signer address is left-aligned in the bytes32.
+		copy(node.Signer[:], signers[i][:])
+		nodes = append(nodes, node)
+	}
+	return method.Outputs.Pack(nodes)
+}
+
+// decodeCallContractRequest returns the contract address and call data of a CallContractRequest.
+func decodeCallContractRequest(request []byte) ([]byte, []byte, error) {
+	call, err := protoBytesField(request, 1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode CallContractRequest: %w", err)
+	}
+
+	to, err := protoBytesField(call, 2)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode CallMsg: %w", err)
+	}
+
+	data, err := protoBytesField(call, 3)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode CallMsg: %w", err)
+	}
+	return to, data, nil
+}
+
+// protoBytesField returns the value of a bytes field in an encoded protobuf message.
+func protoBytesField(msg []byte, field protowire.Number) ([]byte, error) {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		msg = msg[n:]
+
+		if num == field && typ == protowire.BytesType {
+			value, m := protowire.ConsumeBytes(msg)
+			if m < 0 {
+				return nil, protowire.ParseError(m)
+			}
+			return value, nil
+		}
+
+		m := protowire.ConsumeFieldValue(num, typ, msg)
+		if m < 0 {
+			return nil, protowire.ParseError(m)
+		}
+		msg = msg[m:]
+	}
+	return nil, fmt.Errorf("field %d not found", field)
+}
@@ -0,0 +1,75 @@
+package testutils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+)
+
+func TestFakeDON(t *testing.T) {
+	t.Run("generated reports are verified with the registry", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, nil)
+		don := testutils.NewFakeDON(t, 4, 1)
+		don.WorkflowOwner[19] = 0xab
+
+		generated, err := runtime.GenerateReport(&sdk.ReportRequest{EncodedPayload: []byte("payload")}).Await()
+		require.NoError(t, err)
+
+		unwrapped := generated.X_GeneratedCodeOnly_Unwrap()
+		report, err := cre.ParseReport(runtime, unwrapped.RawReport, signatures(unwrapped), unwrapped.ReportContext)
+		require.NoError(t, err)
+		assert.Equal(t, "payload", string(report.Body()))
+		assert.Equal(t, testutils.FakeDONID, report.DONID())
+		header, err := report.Header()
+		require.NoError(t, err)
+		assert.Equal(t, don.WorkflowOwner, header.WorkflowOwner)
+		assert.Equal(t, uint64(1), report.SeqNr())
+	})
+
+	t.Run("created before the runtime", func(t *testing.T) {
+		don := testutils.NewFakeDON(t, 7, 2)
+		don.TimeProvider = func() time.Time { return time.Unix(1774809002, 0) }
+		runtime := testutils.NewRuntime(t, nil)
+
+		generated, err := runtime.GenerateReport(&sdk.ReportRequest{EncodedPayload: []byte("payload")}).Await()
+		require.NoError(t, err)
+
+		unwrapped := generated.X_GeneratedCodeOnly_Unwrap()
+		require.Len(t, unwrapped.Sigs, 3)
+		report, err := cre.VerifyReportOffline(unwrapped.RawReport, signatures(unwrapped), unwrapped.ReportContext, don.StaticDON())
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1774809002), report.Timestamp())
+	})
+
+	t.Run("signs reports offline", func(t *testing.T) {
+		don := testutils.NewFakeDON(t, 4, 1)
+
+		header := cre.ReportHeader{Version: 1, DONID: testutils.FakeDONID, Timestamp: time.Unix(1, 0)}
+		rawReport, err := header.Encode()
+		require.NoError(t, err)
+		reportContext := make([]byte, 96)
+
+		sigs := don.Sign(rawReport, reportContext)
+		require.Len(t, sigs, 2)
+
+		signatures := [][]byte{sigs[0].Signature, sigs[1].Signature}
+		report, err := cre.VerifyReportOffline(rawReport, signatures, reportContext, don.StaticDON())
+		require.NoError(t, err)
+		assert.Equal(t, don.StaticDON().Signers[don.Signers()[0]], report.X_GeneratedCodeOnly_Unwrap().Sigs[0].SignerId)
+	})
+
+}
+
+func signatures(report *sdk.ReportResponse) [][]byte {
+	sigs := make([][]byte, len(report.Sigs))
+	for i, sig := range report.Sigs {
+		sigs[i] = sig.Signature
+	}
+	return sigs
+}
@@ -25,7 +25,8 @@ type Secrets map[Namespace]map[ID]string
 
 // NewRuntime creates a new TestRuntime for use in tests.
 // A nil Secrets map is treated as an empty map, but entries cannot be added later.
-// The secrets map is used directly by the TestRuntime; changes to entries will be reflected in subsequent calls to GetSecret.
+// The secrets map is used directly by the TestRuntime; changes to entries will be reflected in subsequent calls to GetSecret,
+// unless the runtime already retrieved the secret, as secrets are memoized for the execution.
 func NewRuntime(tb testing.TB, secrets Secrets) *TestRuntime {
 	defaultConsensus, err := consensusmock.NewConsensusCapability(tb)
 
@@ -48,8 +49,10 @@ func NewRuntime(tb testing.TB, secrets Secrets) *TestRuntime {
 				Mode:            sdk.Mode_MODE_DON,
 				MaxResponseSize: cre.DefaultMaxResponseSizeBytes,
 				RuntimeHelpers:  &runtimeHelpers{tb: tb, calls: map[int32]chan *sdk.CapabilityResponse{}, secretsCalls: map[int32][]*sdk.SecretResponse{}, secrets: secrets, timeProvider: time.Now},
-				Lggr:            slog.New(slog.NewTextHandler(tw, &slog.HandlerOptions{})),
+				Lggr:            slog.New(sdkimpl.NewModeLogHandler(slog.NewTextHandler(tw, &slog.HandlerOptions{}), sdkimpl.LogModeDON)),
+				Tracer:          sdkimpl.NewTracer(),
 			},
+			Redactor: &sdkimpl.SecretRedactor{},
 		},
 	}
 }
@@ -73,6 +76,23 @@ func (t *TestRuntime) GetLogs() [][]byte {
 	return logs
 }
 
+// Span is a capability call or secret fetch recorded by the TestRuntime.
+type Span = sdkimpl.Span
+
+// SpanSummary aggregates the Spans of one capability method.
+type SpanSummary = sdkimpl.SpanSummary
+
+// Spans returns the capability calls and secret fetches made through the runtime, in the order they were made.
+// Times are read from the runtime's time provider, see SetTimeProvider.
+func (t *TestRuntime) Spans() []Span {
+	return t.Tracer.Spans()
+}
+
+// SpanSummaries aggregates the Spans by capability ID and method, as logged at the end of a traced WASM execution.
+func (t *TestRuntime) SpanSummaries() []SpanSummary {
+	return t.Tracer.Summary()
+}
+
 // SetRandomSource sets the random source used by the DON mode.
 // Note that once the first random is called, changes will have no effect.
 func (t *TestRuntime) SetRandomSource(source rand.Source) {
@@ -280,6 +300,7 @@ func (rh *runtimeHelpers) Now() time.Time {
 // A nil Secrets map is treated as an empty map, but entries cannot be added later.
 func NewTeeRuntime(tb testing.TB, secrets Secrets) *TestTeeRuntime {
 	inner := NewRuntime(tb, secrets)
+	inner.SetLogMode(sdkimpl.LogModeTEE)
 	return &TestTeeRuntime{
 		TestRuntime: inner,
 		TeeRuntime:  sdkimpl.NewTeeRuntime(&inner.Runtime),
@@ -200,3 +200,35 @@ func TestRuntime_Now(t *testing.T) {
 	rt.SetTimeProvider(func() time.Time { return anyNow })
 	assert.Equal(t, anyNow, rt.Now())
 }
+
+func TestRuntime_Spans(t *testing.T) {
+	action, err := basicactionmock.NewBasicActionCapability(t)
+	require.NoError(t, err)
+	action.PerformAction = func(_ context.Context, input *basicaction.Inputs) (*basicaction.Outputs, error) {
+		return &basicaction.Outputs{AdaptedThing: "done"}, nil
+	}
+
+	rt := testutils.NewRuntime(t, testutils.Secrets{"main": {"secret1": "value1"}})
+	_, err = rt.GetSecret(&sdk.SecretRequest{Id: "secret1"}).Await()
+	require.NoError(t, err)
+	for range 2 {
+		_, err = (&basicaction.BasicAction{}).PerformAction(rt, &basicaction.Inputs{InputThing: true}).Await()
+		require.NoError(t, err)
+	}
+
+	spans := rt.Spans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "secrets", spans[0].CapabilityID)
+	assert.Equal(t, "GetSecrets", spans[0].Method)
+	assert.Equal(t, "basic-test-action@1.0.0", spans[1].CapabilityID)
+	assert.Equal(t, "PerformAction", spans[1].Method)
+	assert.Equal(t, sdk.Mode_MODE_DON, spans[1].Mode)
+	assert.Positive(t, spans[1].PayloadSize)
+	assert.Positive(t, spans[1].ResponseSize)
+	assert.False(t, spans[1].End.IsZero())
+
+	summaries := rt.SpanSummaries()
+	require.Len(t, summaries, 2)
+	assert.Equal(t, 2, summaries[1].Calls)
+	assert.Equal(t, 0, summaries[1].Errors)
+}
@@ -0,0 +1,22 @@
+package testutils
+
+import "testing"
+
+// AssertNoSecretsLeaked fails the test if the value of a secret retrieved through the runtime appears in its logs or
+// in any of the outputs, such as a returned error's message.
+// Unlike the WASM runtime, the TestRuntime does not scrub secrets from its logs, so that leaks are caught in tests.
+func AssertNoSecretsLeaked(tb testing.TB, runtime *TestRuntime, outputs ...string) {
+	tb.Helper()
+
+	for i, log := range runtime.GetLogs() {
+		for _, name := range runtime.Redactor.Leaked(string(log)) {
+			tb.Errorf("secret %s leaked in log %d: %s", name, i, runtime.Redactor.Redact(string(log)))
+		}
+	}
+
+	for i, output := range outputs {
+		for _, name := range runtime.Redactor.Leaked(output) {
+			tb.Errorf("secret %s leaked in output %d: %s", name, i, runtime.Redactor.Redact(output))
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package testutils_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-sdk-go/cre/testutils"
+)
+
+func TestAssertNoSecretsLeaked(t *testing.T) {
+	newRuntime := func(t *testing.T) *testutils.TestRuntime {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{"main": {"api_key": "s3cr3t"}})
+		_, err := runtime.GetSecret(&sdk.SecretRequest{Id: "api_key"}).Await()
+		require.NoError(t, err)
+		return runtime
+	}
+
+	t.Run("no leaks", func(t *testing.T) {
+		runtime := newRuntime(t)
+		runtime.Logger().Info("calling API")
+
+		testutils.AssertNoSecretsLeaked(t, runtime, "request failed")
+	})
+
+	t.Run("leaked in logs", func(t *testing.T) {
+		runtime := newRuntime(t)
+		runtime.Logger().Info("calling API", "key", "s3cr3t")
+
+		tb := &recordingTB{TB: t}
+		testutils.AssertNoSecretsLeaked(tb, runtime)
+		require.Len(t, tb.errors, 1)
+		assert.Contains(t, tb.errors[0], "secret main/api_key leaked in log 0")
+		assert.Contains(t, tb.errors[0], "key=[REDACTED]")
+	})
+
+	t.Run("leaked in outputs", func(t *testing.T) {
+		runtime := newRuntime(t)
+
+		tb := &recordingTB{TB: t}
+		testutils.AssertNoSecretsLeaked(tb, runtime, "fine", errors.New("bad key s3cr3t").Error())
+		require.Len(t, tb.errors, 1)
+		assert.Equal(t, "secret main/api_key leaked in output 1: bad key [REDACTED]", tb.errors[0])
+	})
+}
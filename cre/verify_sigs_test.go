@@ -0,0 +1,48 @@
+package cre
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// firstByteVerifier accepts signatures whose first byte is the first byte of a signer key.
+type firstByteVerifier struct{}
+
+func (firstByteVerifier) Digest(_, _ []byte) []byte {
+	return nil
+}
+
+func (firstByteVerifier) Signer(_, signature []byte, signers map[SignerKey]uint32) (SignerKey, error) {
+	for key := range signers {
+		if len(signature) > 0 && key[0] == signature[0] {
+			return key, nil
+		}
+	}
+	return SignerKey{}, errors.New("no signer")
+}
+
+func Test_verifySigs(t *testing.T) {
+	signers := map[SignerKey]uint32{{1}: 7, {2}: 8}
+
+	t.Run("attributes accepted signatures", func(t *testing.T) {
+		report := &sdk.ReportResponse{Sigs: []*sdk.AttributedSignature{{Signature: []byte{1}}, {Signature: []byte{2}}}}
+
+		require.NoError(t, verifySigs(report, 1, signers, firstByteVerifier{}))
+		assert.Equal(t, uint32(7), report.Sigs[0].SignerId)
+		assert.Equal(t, uint32(8), report.Sigs[1].SignerId)
+	})
+
+	t.Run("failed verification leaves the signatures unchanged", func(t *testing.T) {
+		valid := &sdk.AttributedSignature{Signature: []byte{1}, SignerId: 99}
+		sigs := []*sdk.AttributedSignature{valid, {Signature: []byte{3}}}
+		report := &sdk.ReportResponse{Sigs: sigs}
+
+		require.Error(t, verifySigs(report, 1, signers, firstByteVerifier{}))
+		assert.Equal(t, uint32(99), valid.SignerId)
+		assert.Equal(t, sigs, report.Sigs)
+	})
+}
@@ -0,0 +1,62 @@
+package wasm
+
+import "log/slog"
+
+// LogFormat selects how the workflow's log records are written to the host.
+type LogFormat string
+
+const (
+	// LogFormatText writes records with slog.TextHandler. It is the default.
+	LogFormatText LogFormat = "text"
+	// LogFormatJSON writes records with slog.JSONHandler.
+	LogFormatJSON LogFormat = "json"
+)
+
+// RunnerOption configures a Runner created by NewRunner.
+type RunnerOption func(*runnerOptions)
+
+type runnerOptions struct {
+	logFormat   LogFormat
+	logLevel    slog.Leveler
+	logWrappers []func(slog.Handler) slog.Handler
+	tracing     bool
+}
+
+// WithLogFormat selects the format of the workflow's log records.
+func WithLogFormat(format LogFormat) RunnerOption {
+	return func(o *runnerOptions) {
+		o.logFormat = format
+	}
+}
+
+// WithLogLevel sets the minimum level of the records that are logged. The default is slog.LevelInfo.
+func WithLogLevel(level slog.Leveler) RunnerOption {
+	return func(o *runnerOptions) {
+		o.logLevel = level
+	}
+}
+
+// WithLogHandler wraps the handler that writes records to the host, for example to filter or enrich records.
+// Wrappers are applied in the order they are given, so the last one sees records first.
+// Records must be passed on to the wrapped handler to be logged: secrets are only scrubbed there.
+func WithLogHandler(wrap func(slog.Handler) slog.Handler) RunnerOption {
+	return func(o *runnerOptions) {
+		o.logWrappers = append(o.logWrappers, wrap)
+	}
+}
+
+// WithTracing records the time, sizes and error code of each capability call and secret fetch,
+// and logs a summary per capability method when the execution ends.
+func WithTracing() RunnerOption {
+	return func(o *runnerOptions) {
+		o.tracing = true
+	}
+}
+
+func newRunnerOptions(opts []RunnerOption) runnerOptions {
+	var o runnerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
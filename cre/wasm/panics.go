@@ -0,0 +1,79 @@
+package wasm
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/smartcontractkit/cre-sdk-go/internal/sdkimpl"
+)
+
+// maxPanicStackFrames limits how much of the stack is reported for a panic in user code.
+const maxPanicStackFrames = 16
+
+// panicSite describes the part of the workflow that was running when user code panicked.
+type panicSite struct {
+	stage string
+	// handlerIndex is the index of the handler in the workflow, or -1 when the panic is not tied to a handler.
+	handlerIndex int
+	capabilityID string
+}
+
+// exitOnPanic must be deferred directly. It converts a panic in user code into an ExecutionResult_Error
+// so that the host can show operators what went wrong instead of the module dying without a result.
+// Secrets tracked by the redactor are scrubbed from the message.
+func exitOnPanic(r runnerInternals, redactor *sdkimpl.SecretRedactor, site panicSite) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	exitErr(r, redactor.Redact(site.message(recovered, debug.Stack())))
+}
+
+func (s panicSite) message(recovered any, stack []byte) string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "panic in %s: %v", s.stage, recovered)
+	if s.handlerIndex >= 0 {
+		fmt.Fprintf(sb, " (handler index %d, trigger %s)", s.handlerIndex, s.capabilityID)
+	}
+
+	if trimmed := trimPanicStack(stack); trimmed != "" {
+		sb.WriteString("\n")
+		sb.WriteString(trimmed)
+	}
+	return sb.String()
+}
+
+// trimPanicStack removes the goroutine header, the frames of the panic and recovery machinery, and
+// any frames beyond maxPanicStackFrames from a stack returned by debug.Stack.
+// Each frame is a function line followed by a tab-indented file:line line.
+func trimPanicStack(stack []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "goroutine ") {
+		lines = lines[1:]
+	}
+
+	var frames []string
+	for i := 0; i+1 < len(lines); i += 2 {
+		frames = append(frames, lines[i]+"\n"+lines[i+1])
+	}
+
+	// Frames up to and including the call to panic belong to debug.Stack, exitOnPanic and the panic itself.
+	for i := len(frames) - 1; i >= 0; i-- {
+		if strings.HasPrefix(frames[i], "panic(") {
+			frames = frames[i+1:]
+			break
+		}
+	}
+
+	// Runtime errors, such as nil dereferences, are raised from runtime frames below the user's code.
+	for len(frames) > 0 && strings.HasPrefix(frames[0], "runtime.") {
+		frames = frames[1:]
+	}
+
+	if len(frames) > maxPanicStackFrames {
+		frames = append(frames[:maxPanicStackFrames], "...")
+	}
+	return strings.Join(frames, "\n")
+}
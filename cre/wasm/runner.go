@@ -24,13 +24,26 @@ type runnerInternals interface {
 	exit()
 }
 
-func newRunner[C Config](parse func(configBytes []byte) (C, error), runnerInternals runnerInternals, runtimeInternals runtimeInternals) cre.Runner[C] {
+func newRunner[C Config](parse func(configBytes []byte) (C, error), runnerInternals runnerInternals, runtimeInternals runtimeInternals, opts ...RunnerOption) cre.Runner[C] {
 	runnerInternals.versionV2()
 	runnerInternals.switchModes(int32(sdk.Mode_MODE_DON))
-	drt := &sdkimpl.Runtime{RuntimeBase: newRuntime(runtimeInternals, sdk.Mode_MODE_DON)}
+	options := newRunnerOptions(opts)
+	redactor := &sdkimpl.SecretRedactor{}
+	logger := newSlogger(redactor, options)
+	drt := &sdkimpl.Runtime{RuntimeBase: newRuntime(runtimeInternals, sdk.Mode_MODE_DON, logger), Redactor: redactor}
+	if options.tracing {
+		drt.Tracer = sdkimpl.NewTracer()
+	}
 	setRuntime := func(maxResponseSize uint64) {
 		drt.MaxResponseSize = maxResponseSize
 	}
+	setLogger := func(mode string, args ...any) {
+		drt.Lggr = drt.Lggr.With(args...)
+		drt.SetLogMode(mode)
+	}
+	logTrace := func() {
+		drt.Tracer.LogSummary(drt.Lggr)
+	}
 	return runnerWrapper[C]{
 		baseRunner: getRunner(
 			parse,
@@ -45,12 +58,18 @@ func newRunner[C Config](parse func(configBytes []byte) (C, error), runnerIntern
 				switchRuntime:   &switchRuntimeWrapper{Runtime: drt},
 				runnerInternals: runnerInternals,
 				setRuntime:      setRuntime,
+				setLogger:       setLogger,
+				logTrace:        logTrace,
+				redactor:        redactor,
 			},
 			&preHookRunner[C, cre.Runtime]{
 				runnerInternals: runnerInternals,
 				setRuntime:      setRuntime,
+				redactor:        redactor,
 			}),
 		runnerInternals: runnerInternals,
+		redactor:        redactor,
+		logger:          logger,
 	}
 }
 
@@ -61,8 +80,11 @@ type runner[C, T any] struct {
 	runtime       T
 	switchRuntime T
 	setRuntime    func(maxResponseSize uint64)
+	setLogger     func(mode string, args ...any)
+	logTrace      func()
 	config        C
 	sp            cre.SecretsProvider
+	redactor      *sdkimpl.SecretRedactor
 }
 
 var _ baseRunner[any, cre.Runtime] = (*runner[any, cre.Runtime])(nil)
@@ -79,21 +101,27 @@ func (r *runner[C, T]) run(wfs []cre.ExecutionHandler[C, T]) {
 	runtime := r.runtime
 	for idx, handler := range wfs {
 		if uint64(idx) == r.trigger.Id {
+			defer exitOnPanic(r.runnerInternals, r.redactor, panicSite{stage: "callback", handlerIndex: idx, capabilityID: handler.CapabilityID()})
+
+			mode := sdkimpl.LogModeDON
 			if _, ok := handler.(cre.ExecutionHandlerWithRequirements[C, T]); ok {
 				runtime = r.switchRuntime
+				mode = sdkimpl.LogModeTEE
 			}
+			r.setLogger(mode, "handlerIndex", idx, "triggerId", handler.CapabilityID(), "triggerMethod", handler.Method())
 
 			response, err := handler.Callback()(r.config, runtime, r.trigger.Payload)
+			r.logTrace()
 
 			if err == nil {
 				wrapped, err := values.Wrap(response)
 				if err != nil {
-					exit(r.runnerInternals, &sdk.ExecutionResult{Result: &sdk.ExecutionResult_Error{Error: err.Error()}})
+					exitErr(r.runnerInternals, r.redactor.Redact(err.Error()))
 				} else {
 					exit(r.runnerInternals, &sdk.ExecutionResult{Result: &sdk.ExecutionResult_Value{Value: values.Proto(wrapped)}})
 				}
 			} else {
-				exit(r.runnerInternals, &sdk.ExecutionResult{Result: &sdk.ExecutionResult_Error{Error: err.Error()}})
+				exitErr(r.runnerInternals, r.redactor.Redact(err.Error()))
 			}
 		}
 	}
@@ -143,9 +171,11 @@ func (s *subscriber[C, T]) run(wfs []cre.ExecutionHandler[C, T]) {
 }
 
 func (r runnerWrapper[C]) getWorkflows(config C, secretsProvider cre.SecretsProvider, initFn func(C, *slog.Logger, cre.SecretsProvider) (cre.Workflow[C], error)) cre.Workflow[C] {
-	wfs, err := initFn(config, newSlogger(), secretsProvider)
+	defer exitOnPanic(r.runnerInternals, r.redactor, panicSite{stage: "workflow initialization", handlerIndex: -1})
+
+	wfs, err := initFn(config, r.logger, secretsProvider)
 	if err != nil {
-		exitErr(r.runnerInternals, err.Error())
+		exitErr(r.runnerInternals, r.redactor.Redact(err.Error()))
 	}
 	return wfs
 }
@@ -156,6 +186,7 @@ type preHookRunner[C, T any] struct {
 	config     C
 	sp         cre.SecretsProvider
 	setRuntime func(maxResponseSize uint64)
+	redactor   *sdkimpl.SecretRedactor
 }
 
 var _ baseRunner[any, cre.Runtime] = (*preHookRunner[any, cre.Runtime])(nil)
@@ -186,9 +217,11 @@ func (p *preHookRunner[C, T]) run(wfs []cre.ExecutionHandler[C, T]) {
 		return
 	}
 
+	defer exitOnPanic(p.runnerInternals, p.redactor, panicSite{stage: "preHook", handlerIndex: idx, capabilityID: preHookHandler.CapabilityID()})
+
 	restrictions, err := preHookHandler.PreHook(p.config, p.trigger.Payload)
 	if err != nil {
-		exitErr(p.runnerInternals, err.Error())
+		exitErr(p.runnerInternals, p.redactor.Redact(err.Error()))
 		return
 	}
 
@@ -265,6 +298,8 @@ type baseRunner[C, T any] interface {
 type runnerWrapper[C any] struct {
 	baseRunner[C, cre.Runtime]
 	runnerInternals runnerInternals
+	redactor        *sdkimpl.SecretRedactor
+	logger          *slog.Logger
 }
 
 func (r runnerWrapper[C]) Run(initFn func(config C, logger *slog.Logger, secretsProvider cre.SecretsProvider) (cre.Workflow[C], error)) {
@@ -3,7 +3,9 @@ package wasm
 import (
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
@@ -366,6 +368,36 @@ func TestHandlerWithPreHook(t *testing.T) {
 		assert.Equal(t, uint32(2), method.MaxCalls)
 	})
 
+	t.Run("does not run the preHook for the execution", func(t *testing.T) {
+		dr := getTestRunner(t, anyExecuteRequest)
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			return cre.Workflow[string]{
+				cre.HandlerWithPreHook(
+					basictrigger.Trigger(testworkflow.TestWorkflowTriggerConfig()),
+					func(string, cre.Runtime, *basictrigger.Outputs) (string, error) {
+						return "done", nil
+					},
+					func(string, *basictrigger.Outputs) (*sdk.Restrictions, error) {
+						assert.Fail(t, "preHook should not be invoked")
+						return nil, errors.New("unexpected preHook")
+					},
+				),
+			}, nil
+		})
+
+		actual := &sdk.ExecutionResult{}
+		sentResponse := dr.(runnerWrapper[string]).baseRunner.(*runner[string, cre.Runtime]).runnerInternals.(*runnerInternalsTestHook).sentResponse
+		require.NoError(t, proto.Unmarshal(sentResponse, actual))
+
+		result, ok := actual.Result.(*sdk.ExecutionResult_Value)
+		require.True(t, ok, "expected value result, got %T", actual.Result)
+		v, err := values.FromProto(result.Value)
+		require.NoError(t, err)
+		returnedValue, err := v.Unwrap()
+		require.NoError(t, err)
+		assert.Equal(t, "done", returnedValue)
+	})
+
 	t.Run("returns error when no preHook is registered", func(t *testing.T) {
 		dr := getTestRunner(t, anyPreHookRequest)
 		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
@@ -470,6 +502,227 @@ func TestHandlerWithPreHook(t *testing.T) {
 	})
 }
 
+func TestRunner_RecoversPanics(t *testing.T) {
+	t.Run("callback", func(t *testing.T) {
+		dr := getTestRunner(t, anyExecuteRequest)
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			return cre.Workflow[string]{
+				cre.Handler(
+					basictrigger.Trigger(testworkflow.TestWorkflowTriggerConfig()),
+					func(string, cre.Runtime, *basictrigger.Outputs) (int, error) {
+						panic("boom")
+					}),
+			}, nil
+		})
+
+		errMsg := sentError(t, dr.(runnerWrapper[string]).baseRunner.(*runner[string, cre.Runtime]).runnerInternals)
+		assert.True(t, strings.HasPrefix(errMsg, "panic in callback: boom (handler index 0, trigger "+capID+")\n"), errMsg)
+		assert.Contains(t, errMsg, "runner_test.go")
+		assert.NotContains(t, errMsg, "runtime/debug")
+		assert.NotContains(t, errMsg, "goroutine")
+	})
+
+	t.Run("callback runtime error", func(t *testing.T) {
+		dr := getTestRunner(t, anyExecuteRequest)
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			return cre.Workflow[string]{
+				cre.Handler(
+					basictrigger.Trigger(testworkflow.TestWorkflowTriggerConfig()),
+					func(_ string, _ cre.Runtime, outputs *basictrigger.Outputs) (int, error) {
+						var nilOutputs *basictrigger.Outputs
+						return len(nilOutputs.CoolOutput), nil
+					}),
+			}, nil
+		})
+
+		errMsg := sentError(t, dr.(runnerWrapper[string]).baseRunner.(*runner[string, cre.Runtime]).runnerInternals)
+		assert.Contains(t, errMsg, "panic in callback: runtime error: invalid memory address or nil pointer dereference")
+		assert.Contains(t, errMsg, "(handler index 0, trigger "+capID+")")
+		assert.NotContains(t, errMsg, "runtime.sigpanic")
+	})
+
+	t.Run("preHook", func(t *testing.T) {
+		dr := getTestRunner(t, anyPreHookRequest)
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			return cre.Workflow[string]{
+				cre.HandlerWithPreHook(
+					basictrigger.Trigger(testworkflow.TestWorkflowTriggerConfig()),
+					func(string, cre.Runtime, *basictrigger.Outputs) (int, error) { return 0, nil },
+					func(string, *basictrigger.Outputs) (*sdk.Restrictions, error) {
+						panic(errors.New("boom"))
+					},
+				),
+			}, nil
+		})
+
+		errMsg := sentError(t, dr.(runnerWrapper[string]).baseRunner.(*preHookRunner[string, cre.Runtime]).runnerInternals)
+		assert.True(t, strings.HasPrefix(errMsg, "panic in preHook: boom (handler index 0, trigger "+capID+")\n"), errMsg)
+	})
+
+	t.Run("workflow initialization", func(t *testing.T) {
+		dr := getTestRunner(t, anyExecuteRequest)
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			panic("boom")
+		})
+
+		errMsg := sentError(t, dr.(runnerWrapper[string]).baseRunner.(*runner[string, cre.Runtime]).runnerInternals)
+		assert.True(t, strings.HasPrefix(errMsg, "panic in workflow initialization: boom\n"), errMsg)
+		assert.NotContains(t, errMsg, "handler index")
+	})
+}
+
+func TestRunner_LogsExecutionAttributes(t *testing.T) {
+	t.Run("DON handler", func(t *testing.T) {
+		logs = make([][]byte, 0)
+		dr := getTestRunner(t, anyExecuteRequest)
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			return cre.Workflow[string]{
+				cre.Handler(
+					basictrigger.Trigger(testworkflow.TestWorkflowTriggerConfig()),
+					func(_ string, rt cre.Runtime, _ *basictrigger.Outputs) (int, error) {
+						rt.Logger().Info("handling")
+						return 0, nil
+					}),
+			}, nil
+		})
+
+		require.Len(t, logs, 1)
+		assert.Contains(t, string(logs[0]), "msg=handling handlerIndex=0 triggerId="+capID+" triggerMethod=Trigger mode=DON")
+	})
+
+	t.Run("TEE handler", func(t *testing.T) {
+		logs = make([][]byte, 0)
+		dr := getTestRunner(t, anyExecuteRequest)
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			return cre.Workflow[string]{
+				cre.HandlerInTee(
+					basictrigger.Trigger(testworkflow.TestWorkflowTriggerConfig()),
+					func(_ string, rt cre.TeeRuntime, _ *basictrigger.Outputs) (int, error) {
+						rt.Logger().Info("handling")
+						return 0, nil
+					},
+					cre.AnyTeeInRegions{Regions: []cre.Region{cre.AwsUsWest2}}),
+			}, nil
+		})
+
+		require.Len(t, logs, 1)
+		assert.Contains(t, string(logs[0]), "mode=TEE")
+	})
+
+	t.Run("runner log options", func(t *testing.T) {
+		logs = make([][]byte, 0)
+		dr := newRunner(func(b []byte) (string, error) { return string(b), nil }, testRunnerInternals(t, anyExecuteRequest), testRuntimeInternals(t), WithLogFormat(LogFormatJSON), WithLogLevel(slog.LevelDebug))
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			return cre.Workflow[string]{
+				cre.Handler(
+					basictrigger.Trigger(testworkflow.TestWorkflowTriggerConfig()),
+					func(_ string, rt cre.Runtime, _ *basictrigger.Outputs) (int, error) {
+						rt.Logger().Debug("handling")
+						return 0, nil
+					}),
+			}, nil
+		})
+
+		require.Len(t, logs, 1)
+		assert.Contains(t, string(logs[0]), `"level":"DEBUG","msg":"handling","handlerIndex":0`)
+		assert.Contains(t, string(logs[0]), `"mode":"DON"`)
+	})
+}
+
+func TestRunner_RedactsSecrets(t *testing.T) {
+	secret := &sdk.Secret{Namespace: cre.DefaultSecretNamespace, Id: "api_key", Value: "s3cr3t"}
+	newSecretsRunner := func(t *testing.T) cre.Runner[string] {
+		internals := testRuntimeInternals(t)
+		internals.secrets[secretKey(secret.Namespace, secret.Id)] = secret
+		return newRunner(func(b []byte) (string, error) { return string(b), nil }, testRunnerInternals(t, anyExecuteRequest), internals)
+	}
+
+	t.Run("callback error and logs", func(t *testing.T) {
+		logs = make([][]byte, 0)
+		dr := newSecretsRunner(t)
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			return cre.Workflow[string]{
+				cre.Handler(
+					basictrigger.Trigger(testworkflow.TestWorkflowTriggerConfig()),
+					func(_ string, rt cre.Runtime, _ *basictrigger.Outputs) (int, error) {
+						s, err := rt.GetSecret(&sdk.SecretRequest{Id: secret.Id}).Await()
+						require.NoError(t, err)
+						rt.Logger().Info("calling API", "key", s.Value)
+						return 0, fmt.Errorf("API rejected key %s", s.Value)
+					}),
+			}, nil
+		})
+
+		errMsg := sentError(t, dr.(runnerWrapper[string]).baseRunner.(*runner[string, cre.Runtime]).runnerInternals)
+		assert.Equal(t, "API rejected key [REDACTED]", errMsg)
+		require.NotEmpty(t, logs)
+		assert.Contains(t, string(logs[len(logs)-1]), "key=[REDACTED]")
+		for _, log := range logs {
+			assert.NotContains(t, string(log), secret.Value)
+		}
+	})
+
+	t.Run("workflow initialization error", func(t *testing.T) {
+		dr := newSecretsRunner(t)
+		dr.Run(func(_ string, _ *slog.Logger, secretsProvider cre.SecretsProvider) (cre.Workflow[string], error) {
+			s, err := secretsProvider.GetSecret(&sdk.SecretRequest{Id: secret.Id}).Await()
+			require.NoError(t, err)
+			return nil, fmt.Errorf("bad key %q", s.Value)
+		})
+
+		errMsg := sentError(t, dr.(runnerWrapper[string]).baseRunner.(*runner[string, cre.Runtime]).runnerInternals)
+		assert.Equal(t, `bad key "[REDACTED]"`, errMsg)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		dr := newSecretsRunner(t)
+		dr.Run(func(string, *slog.Logger, cre.SecretsProvider) (cre.Workflow[string], error) {
+			return cre.Workflow[string]{
+				cre.Handler(
+					basictrigger.Trigger(testworkflow.TestWorkflowTriggerConfig()),
+					func(_ string, rt cre.Runtime, _ *basictrigger.Outputs) (int, error) {
+						s, err := rt.GetSecret(&sdk.SecretRequest{Id: secret.Id}).Await()
+						require.NoError(t, err)
+						panic(s.Value)
+					}),
+			}, nil
+		})
+
+		errMsg := sentError(t, dr.(runnerWrapper[string]).baseRunner.(*runner[string, cre.Runtime]).runnerInternals)
+		assert.True(t, strings.HasPrefix(errMsg, "panic in callback: [REDACTED] "), errMsg)
+	})
+}
+
+func TestTrimPanicStack(t *testing.T) {
+	stack := "goroutine 1 [running]:\n" +
+		"runtime/debug.Stack()\n\t/go/src/runtime/debug/stack.go:26 +0x5e\n" +
+		"github.com/smartcontractkit/cre-sdk-go/cre/wasm.exitOnPanic(...)\n\t/cre/wasm/panics.go:28 +0x1\n" +
+		"panic({0x1, 0x2})\n\t/go/src/runtime/panic.go:791 +0x132\n" +
+		"runtime.panicmem(...)\n\t/go/src/runtime/panic.go:262\n" +
+		"runtime.sigpanic()\n\t/go/src/runtime/signal_unix.go:925 +0x359\n" +
+		"main.callback(...)\n\t/workflow/main.go:10 +0x1\n" +
+		"main.main()\n\t/workflow/main.go:20 +0x2\n"
+
+	assert.Equal(t, "main.callback(...)\n\t/workflow/main.go:10 +0x1\nmain.main()\n\t/workflow/main.go:20 +0x2", trimPanicStack([]byte(stack)))
+
+	var long strings.Builder
+	for i := range maxPanicStackFrames + 5 {
+		fmt.Fprintf(&long, "main.f%d()\n\t/workflow/main.go:%d\n", i, i)
+	}
+	trimmed := strings.Split(trimPanicStack([]byte(long.String())), "\n")
+	assert.Len(t, trimmed, 2*maxPanicStackFrames+1)
+	assert.Equal(t, "...", trimmed[len(trimmed)-1])
+}
+
+func sentError(t *testing.T, internals runnerInternals) string {
+	actual := &sdk.ExecutionResult{}
+	require.NoError(t, proto.Unmarshal(internals.(*runnerInternalsTestHook).sentResponse, actual))
+
+	errResult, ok := actual.Result.(*sdk.ExecutionResult_Error)
+	require.True(t, ok, "expected error result, got %T", actual.Result)
+	return errResult.Error
+}
+
 func assertEnv(t *testing.T, r cre.Runner[string]) {
 	ran := false
 	verifyEnv := func(config string, logger *slog.Logger, secretsProvider cre.SecretsProvider) (cre.Workflow[string], error) {
@@ -20,8 +20,9 @@ func switchModes(mode int32)
 func now(response unsafe.Pointer) int32
 
 // NewRunner creates a new cre.Runner instance with the provided function to parse config.
-func NewRunner[C Config](parse func(configBytes []byte) (C, error)) cre.Runner[C] {
-	return newRunner[C](parse, runnerInternalsImpl{}, runtimeInternalsImpl{})
+// Options such as WithLogFormat and WithLogLevel configure the logger given to the workflow.
+func NewRunner[C Config](parse func(configBytes []byte) (C, error), opts ...RunnerOption) cre.Runner[C] {
+	return newRunner[C](parse, runnerInternalsImpl{}, runtimeInternalsImpl{}, opts...)
 }
 
 type runnerInternalsImpl struct{}
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"time"
 	"unsafe"
@@ -27,11 +28,11 @@ type runtimeInternals interface {
 	now(response unsafe.Pointer) int32
 }
 
-func newRuntime(internals runtimeInternals, mode sdk.Mode) sdkimpl.RuntimeBase {
+func newRuntime(internals runtimeInternals, mode sdk.Mode, logger *slog.Logger) sdkimpl.RuntimeBase {
 	return sdkimpl.RuntimeBase{
 		Mode:           mode,
 		RuntimeHelpers: &runtimeHelper{runtimeInternals: internals},
-		Lggr:           newSlogger(),
+		Lggr:           logger,
 	}
 }
 
@@ -39,6 +40,14 @@ type runtimeHelper struct {
 	runtimeInternals
 	donSource  rand.Source
 	nodeSource rand.Source
+
+	// response is the buffer the host writes responses to, allocated once and reused by every host call.
+	// Responses are unmarshalled before the next host call, and unmarshalling copies the bytes, so reuse is safe.
+	// The buffer is MaxResponseSize bytes and deliberately stays pinned for the life of the module: the guest runs
+	// a single execution, and keeping it avoids a multi-megabyte allocation per host call.
+	// TODO: grow the buffer on demand once the host can report the size of a response that did not fit;
+	// until then, responses larger than MaxResponseSize still fail with the host's ResponseBufferTooSmall error.
+	response []byte
 }
 
 func (r *runtimeHelper) GetSource(mode sdk.Mode) rand.Source {
@@ -69,7 +78,7 @@ func (r *runtimeHelper) GetSecrets(request *sdk.GetSecretsRequest, maxResponseSi
 		return err
 	}
 
-	response := make([]byte, maxResponseSize)
+	response := r.responseBuffer(maxResponseSize)
 	responsePtr, responseLen, err := bufferToPointerLen(response)
 	if err != nil {
 		return err
@@ -94,7 +103,7 @@ func (r *runtimeHelper) AwaitSecrets(request *sdk.AwaitSecretsRequest, maxRespon
 		return nil, err
 	}
 
-	response := make([]byte, maxResponseSize)
+	response := r.responseBuffer(maxResponseSize)
 	responsePtr, responseLen, err := bufferToPointerLen(response)
 	if err != nil {
 		return nil, err
@@ -145,7 +154,7 @@ func (r *runtimeHelper) Await(request *sdk.AwaitCapabilitiesRequest, maxResponse
 		return nil, err
 	}
 
-	response := make([]byte, maxResponseSize)
+	response := r.responseBuffer(maxResponseSize)
 	responsePtr, responseLen, err := bufferToPointerLen(response)
 	if err != nil {
 		return nil, err
@@ -165,6 +174,14 @@ func (r *runtimeHelper) Await(request *sdk.AwaitCapabilitiesRequest, maxResponse
 	return awaitResponse, nil
 }
 
+// responseBuffer returns the reused response buffer, allocating it when maxResponseSize changes.
+func (r *runtimeHelper) responseBuffer(maxResponseSize uint64) []byte {
+	if uint64(len(r.response)) != maxResponseSize {
+		r.response = make([]byte, maxResponseSize)
+	}
+	return r.response
+}
+
 func (r *runtimeHelper) SwitchModes(mode sdk.Mode) {
 	r.switchModes(int32(mode))
 }
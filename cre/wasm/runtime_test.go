@@ -78,6 +78,18 @@ func TestRuntimeBase_CallCapability(t *testing.T) {
 	})
 }
 
+func Test_runtimeHelper_responseBuffer(t *testing.T) {
+	helper := &runtimeHelper{}
+
+	first := helper.responseBuffer(cre.DefaultMaxResponseSizeBytes)
+	require.Len(t, first, cre.DefaultMaxResponseSizeBytes)
+	second := helper.responseBuffer(cre.DefaultMaxResponseSizeBytes)
+	assert.Same(t, &first[0], &second[0], "the buffer is reused while the max response size is unchanged")
+
+	resized := helper.responseBuffer(1024)
+	assert.Len(t, resized, 1024)
+}
+
 func Test_runtimeInternals_UsesSeeds(t *testing.T) {
 	anyDonSeed := int64(123456789)
 	anyNodeSeed := int64(987654321)
@@ -136,7 +148,7 @@ func newTestRuntime(t *testing.T, callCapabilityErr bool, awaitResponseOverride
 		internals.secrets[secretKey(s.Namespace, s.Id)] = s
 	}
 
-	runtime := newRuntime(internals, sdkpb.Mode_MODE_DON)
+	runtime := newRuntime(internals, sdkpb.Mode_MODE_DON, newSlogger(nil, runnerOptions{}))
 	runtime.MaxResponseSize = cre.DefaultMaxResponseSizeBytes
 	return runtime
 }
@@ -3,6 +3,8 @@ package wasm
 import (
 	"io"
 	"log/slog"
+
+	"github.com/smartcontractkit/cre-sdk-go/internal/sdkimpl"
 )
 
 type writer struct{}
@@ -19,6 +21,34 @@ func (w *writer) Write(p []byte) (n int, err error) {
 
 var _ io.Writer = (*writer)(nil)
 
-func newSlogger() *slog.Logger {
-	return slog.New(slog.NewTextHandler(&writer{}, nil))
+// redactingWriter scrubs the secrets tracked by the redactor from log lines before they reach the host.
+type redactingWriter struct {
+	io.Writer
+	redactor *sdkimpl.SecretRedactor
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	if _, err := w.Writer.Write([]byte(w.redactor.Redact(string(p)))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// newSlogger creates a logger that writes to the host in the configured format and reports the runtime's mode
+// with each record, see sdkimpl.ModeLogHandler.
+func newSlogger(redactor *sdkimpl.SecretRedactor, options runnerOptions) *slog.Logger {
+	w := &redactingWriter{Writer: &writer{}, redactor: redactor}
+	handlerOptions := &slog.HandlerOptions{Level: options.logLevel}
+
+	var handler slog.Handler
+	if options.logFormat == LogFormatJSON {
+		handler = slog.NewJSONHandler(w, handlerOptions)
+	} else {
+		handler = slog.NewTextHandler(w, handlerOptions)
+	}
+
+	for _, wrap := range options.logWrappers {
+		handler = wrap(handler)
+	}
+	return slog.New(sdkimpl.NewModeLogHandler(handler, sdkimpl.LogModeDON))
 }
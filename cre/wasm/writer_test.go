@@ -1,9 +1,15 @@
 package wasm
 
 import (
+	"encoding/json"
+	"log/slog"
 	"testing"
 
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-sdk-go/internal/sdkimpl"
 )
 
 func TestSyncer(t *testing.T) {
@@ -22,3 +28,56 @@ func TestSyncer(t *testing.T) {
 	assert.Equal(t, "Hello, World!", string(logs[0]))
 	assert.Equal(t, "Again", string(logs[1]))
 }
+
+func TestNewSlogger(t *testing.T) {
+	t.Run("defaults to text at info level", func(t *testing.T) {
+		logs = make([][]byte, 0)
+		logger := newSlogger(nil, newRunnerOptions(nil))
+
+		logger.Debug("hidden")
+		logger.Info("shown", "key", "value")
+
+		require.Len(t, logs, 1)
+		assert.Contains(t, string(logs[0]), "level=INFO msg=shown key=value mode=DON")
+	})
+
+	t.Run("JSON at debug level", func(t *testing.T) {
+		logs = make([][]byte, 0)
+		logger := newSlogger(nil, newRunnerOptions([]RunnerOption{WithLogFormat(LogFormatJSON), WithLogLevel(slog.LevelDebug)}))
+
+		logger.Debug("shown", "key", "value")
+
+		require.Len(t, logs, 1)
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(logs[0], &record))
+		assert.Equal(t, "DEBUG", record["level"])
+		assert.Equal(t, "shown", record["msg"])
+		assert.Equal(t, "value", record["key"])
+		assert.Equal(t, "DON", record["mode"])
+	})
+
+	t.Run("custom handler wrappers", func(t *testing.T) {
+		logs = make([][]byte, 0)
+		addWorkflow := func(h slog.Handler) slog.Handler {
+			return h.WithAttrs([]slog.Attr{slog.String("workflow", "prices")})
+		}
+		logger := newSlogger(nil, newRunnerOptions([]RunnerOption{WithLogHandler(addWorkflow)}))
+
+		logger.Info("shown")
+
+		require.Len(t, logs, 1)
+		assert.Contains(t, string(logs[0]), "msg=shown workflow=prices mode=DON")
+	})
+
+	t.Run("secrets are scrubbed from JSON", func(t *testing.T) {
+		logs = make([][]byte, 0)
+		redactor := &sdkimpl.SecretRedactor{}
+		redactor.Track(&sdk.Secret{Namespace: "main", Id: "quoted", Value: `pa"ss`})
+		logger := newSlogger(redactor, newRunnerOptions([]RunnerOption{WithLogFormat(LogFormatJSON)}))
+
+		logger.Info("login", "password", `pa"ss`)
+
+		require.Len(t, logs, 1)
+		assert.Contains(t, string(logs[0]), `"password":"[REDACTED]"`)
+	})
+}
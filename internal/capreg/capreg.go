@@ -0,0 +1,9 @@
+// Package capreg holds the ABI of the capabilities registry, which is read to verify the signatures on CRE reports.
+package capreg
+
+import _ "embed"
+
+// V2ABI is the JSON ABI of the capabilities registry's getDON and getNodesByP2PIds functions.
+//
+//go:embed capreg_abi.json
+var V2ABI string
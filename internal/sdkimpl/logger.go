@@ -0,0 +1,93 @@
+package sdkimpl
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Modes reported in the mode attribute of log records.
+const (
+	LogModeDON  = "DON"
+	LogModeNode = "Node"
+	LogModeTEE  = "TEE"
+)
+
+// ModeLogHandler adds the mode the runtime is in when a record is handled as the record's mode attribute.
+// Loggers derived from it share the mode, so a logger obtained in DON mode reports Node mode inside RunInNodeMode.
+// Once a call was made to the host, the record's callId attribute holds the callback ID of the latest one,
+// so log lines can be matched with the calls traced around them.
+// Records have no workflow or execution ID attributes: the ExecuteRequest the host sends does not carry them.
+type ModeLogHandler struct {
+	slog.Handler
+	mode   *string
+	callID *int32
+}
+
+var _ slog.Handler = (*ModeLogHandler)(nil)
+
+// NewModeLogHandler wraps handler, starting in the given mode.
+func NewModeLogHandler(handler slog.Handler, mode string) *ModeLogHandler {
+	return &ModeLogHandler{Handler: handler, mode: &mode, callID: new(int32)}
+}
+
+// SetMode changes the mode of every logger sharing the handler.
+func (h *ModeLogHandler) SetMode(mode string) {
+	*h.mode = mode
+}
+
+// Mode returns the mode reported by every logger sharing the handler.
+func (h *ModeLogHandler) Mode() string {
+	return *h.mode
+}
+
+// SetCallID changes the call ID of every logger sharing the handler.
+func (h *ModeLogHandler) SetCallID(callID int32) {
+	*h.callID = callID
+}
+
+func (h *ModeLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.AddAttrs(slog.String("mode", *h.mode))
+	if *h.callID != 0 {
+		record.AddAttrs(slog.Int("callId", int(*h.callID)))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *ModeLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ModeLogHandler{Handler: h.Handler.WithAttrs(attrs), mode: h.mode, callID: h.callID}
+}
+
+func (h *ModeLogHandler) WithGroup(name string) slog.Handler {
+	return &ModeLogHandler{Handler: h.Handler.WithGroup(name), mode: h.mode, callID: h.callID}
+}
+
+// SetLogMode updates the mode attribute of the runtime's logger, if it has a ModeLogHandler.
+func (r *RuntimeBase) SetLogMode(mode string) {
+	if r.Lggr == nil {
+		return
+	}
+	if h, ok := r.Lggr.Handler().(*ModeLogHandler); ok {
+		h.SetMode(mode)
+	}
+}
+
+// logMode returns the mode attribute of the runtime's logger, or "" if it has no ModeLogHandler.
+func (r *RuntimeBase) logMode() string {
+	if r.Lggr == nil {
+		return ""
+	}
+	if h, ok := r.Lggr.Handler().(*ModeLogHandler); ok {
+		return h.Mode()
+	}
+	return ""
+}
+
+// setLogCallID updates the callId attribute of the runtime's logger, if it has a ModeLogHandler.
+func (r *RuntimeBase) setLogCallID(callID int32) {
+	if r.Lggr == nil {
+		return
+	}
+	if h, ok := r.Lggr.Handler().(*ModeLogHandler); ok {
+		h.SetCallID(callID)
+	}
+}
@@ -0,0 +1,40 @@
+package sdkimpl_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/smartcontractkit/cre-sdk-go/internal/sdkimpl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModeLogHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := sdkimpl.NewModeLogHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{}), sdkimpl.LogModeDON)
+	logger := slog.New(handler).With("handlerIndex", 0)
+
+	logger.Info("first")
+	handler.SetMode(sdkimpl.LogModeNode)
+	logger.Info("second")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), "msg=first handlerIndex=0 mode=DON")
+	assert.Contains(t, string(lines[1]), "msg=second handlerIndex=0 mode=Node")
+}
+
+func TestModeLogHandler_CallID(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := sdkimpl.NewModeLogHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{}), sdkimpl.LogModeDON)
+	logger := slog.New(handler).With("handlerIndex", 0)
+
+	logger.Info("before")
+	handler.SetCallID(-2)
+	logger.Info("after")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.NotContains(t, string(lines[0]), "callId")
+	assert.Contains(t, string(lines[1]), "msg=after handlerIndex=0 mode=DON callId=-2")
+}
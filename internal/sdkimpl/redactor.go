@@ -0,0 +1,97 @@
+package sdkimpl
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+)
+
+// RedactedSecret replaces secret values scrubbed by SecretRedactor.
+const RedactedSecret = "[REDACTED]"
+
+// SecretRedactor tracks the secret values returned during an execution so they can be scrubbed from logs and
+// errors before they leave the guest. Values are matched as-is and in the escaped forms used by slog's text and
+// JSON handlers. A nil SecretRedactor tracks and redacts nothing.
+type SecretRedactor struct {
+	lock     sync.Mutex
+	secrets  []*sdk.Secret
+	replacer *strings.Replacer
+}
+
+// Track adds the secret's value to the values that are redacted.
+func (r *SecretRedactor) Track(secret *sdk.Secret) {
+	if r == nil || secret.GetValue() == "" {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.secrets = append(r.secrets, secret)
+	r.replacer = nil
+}
+
+// Redact replaces every tracked secret value in s with RedactedSecret.
+func (r *SecretRedactor) Redact(s string) string {
+	if r == nil {
+		return s
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.secrets) == 0 {
+		return s
+	}
+
+	if r.replacer == nil {
+		var forms []string
+		for _, secret := range r.secrets {
+			forms = append(forms, secretForms(secret.Value)...)
+		}
+
+		// strings.Replacer prefers earlier arguments, so longer values are listed first to be replaced whole.
+		slices.SortFunc(forms, func(a, b string) int { return len(b) - len(a) })
+		forms = slices.Compact(forms)
+		oldNew := make([]string, 0, 2*len(forms))
+		for _, form := range forms {
+			oldNew = append(oldNew, form, RedactedSecret)
+		}
+		r.replacer = strings.NewReplacer(oldNew...)
+	}
+	return r.replacer.Replace(s)
+}
+
+// Leaked returns the names, as namespace/id, of the tracked secrets whose values appear in s.
+func (r *SecretRedactor) Leaked(s string) []string {
+	if r == nil {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var leaked []string
+	for _, secret := range r.secrets {
+		for _, form := range secretForms(secret.Value) {
+			if strings.Contains(s, form) {
+				leaked = append(leaked, secret.Namespace+"/"+secret.Id)
+				break
+			}
+		}
+	}
+	return leaked
+}
+
+// secretForms returns the value along with its escaped forms when quoted by the text and JSON handlers.
+func secretForms(value string) []string {
+	forms := []string{value}
+	if quoted := strconv.Quote(value); quoted[1:len(quoted)-1] != value {
+		forms = append(forms, quoted[1:len(quoted)-1])
+	}
+	if encoded, err := json.Marshal(value); err == nil && string(encoded[1:len(encoded)-1]) != value {
+		forms = append(forms, string(encoded[1:len(encoded)-1]))
+	}
+	return forms
+}
@@ -0,0 +1,50 @@
+package sdkimpl_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/smartcontractkit/cre-sdk-go/internal/sdkimpl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretRedactor(t *testing.T) {
+	t.Run("redacts tracked values", func(t *testing.T) {
+		redactor := &sdkimpl.SecretRedactor{}
+		redactor.Track(&sdk.Secret{Namespace: "main", Id: "short", Value: "abc"})
+		redactor.Track(&sdk.Secret{Namespace: "main", Id: "long", Value: "abcdef"})
+
+		assert.Equal(t, "[REDACTED] and [REDACTED]", redactor.Redact("abcdef and abc"))
+	})
+
+	t.Run("redacts escaped values", func(t *testing.T) {
+		redactor := &sdkimpl.SecretRedactor{}
+		redactor.Track(&sdk.Secret{Namespace: "main", Id: "quoted", Value: "pa\"ss\nword"})
+
+		assert.Equal(t, `msg="key [REDACTED]"`, redactor.Redact(`msg="key pa\"ss\nword"`))
+	})
+
+	t.Run("reports leaked secrets", func(t *testing.T) {
+		redactor := &sdkimpl.SecretRedactor{}
+		redactor.Track(&sdk.Secret{Namespace: "main", Id: "one", Value: "first"})
+		redactor.Track(&sdk.Secret{Namespace: "db", Id: "two", Value: "second"})
+
+		assert.Equal(t, []string{"db/two"}, redactor.Leaked("the second value"))
+		assert.Empty(t, redactor.Leaked("nothing"))
+	})
+
+	t.Run("empty values are not tracked", func(t *testing.T) {
+		redactor := &sdkimpl.SecretRedactor{}
+		redactor.Track(&sdk.Secret{Namespace: "main", Id: "empty"})
+
+		assert.Equal(t, "unchanged", redactor.Redact("unchanged"))
+	})
+
+	t.Run("nil redactor", func(t *testing.T) {
+		var redactor *sdkimpl.SecretRedactor
+		redactor.Track(&sdk.Secret{Value: "value"})
+
+		assert.Equal(t, "value", redactor.Redact("value"))
+		assert.Empty(t, redactor.Leaked("value"))
+	})
+}
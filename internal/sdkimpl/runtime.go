@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math/rand"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
@@ -38,6 +38,8 @@ type RuntimeBase struct {
 	MaxResponseSize uint64
 	RuntimeHelpers
 	Lggr *slog.Logger
+	// Tracer records the capability calls and secret fetches when set.
+	Tracer *Tracer
 
 	source   rand.Source
 	source64 rand.Source64
@@ -60,9 +62,10 @@ func (r *RuntimeBase) Logger() *slog.Logger {
 }
 
 var (
-	_ cre.RuntimeBase = (*RuntimeBase)(nil)
-	_ rand.Source     = (*RuntimeBase)(nil)
-	_ rand.Source64   = (*RuntimeBase)(nil)
+	_ cre.RuntimeBase  = (*RuntimeBase)(nil)
+	_ cre.BatchAwaiter = (*RuntimeBase)(nil)
+	_ rand.Source      = (*RuntimeBase)(nil)
+	_ rand.Source64    = (*RuntimeBase)(nil)
 )
 
 func (r *RuntimeBase) CallCapability(request *sdk.CapabilityRequest) cre.Promise[*sdk.CapabilityResponse] {
@@ -78,31 +81,29 @@ func (r *RuntimeBase) CallCapability(request *sdk.CapabilityRequest) cre.Promise
 
 	myId := r.nextCallId
 	request.CallbackId = myId
+	r.setLogCallID(myId)
 	if r.modeErr != nil {
 		return cre.PromiseFromResult[*sdk.CapabilityResponse](nil, r.modeErr)
 	}
 
 	err := r.RuntimeHelpers.Call(request)
+	r.traceCall(request, err)
 	if err != nil {
 		return cre.PromiseFromResult[*sdk.CapabilityResponse](nil, err)
 	}
 
-	return cre.NewBasicPromise(func() (*sdk.CapabilityResponse, error) {
-		awaitRequest := &sdk.AwaitCapabilitiesRequest{
-			Ids: []int32{myId},
-		}
-		awaitResponse, err := r.Await(awaitRequest, r.MaxResponseSize)
-		if err != nil {
-			return nil, err
-		}
+	return cre.NewCapabilityPromise(myId, r)
+}
 
-		capResponse, ok := awaitResponse.Responses[myId]
-		if !ok {
-			return nil, fmt.Errorf("cannot find response for %d", myId)
-		}
+// AwaitCapabilities awaits the outstanding capability calls with the given callback IDs in a single host call.
+func (r *RuntimeBase) AwaitCapabilities(ids []int32) (map[int32]*sdk.CapabilityResponse, error) {
+	awaitResponse, err := r.Await(&sdk.AwaitCapabilitiesRequest{Ids: ids}, r.MaxResponseSize)
+	r.traceAwait(ids, awaitResponse.GetResponses(), err)
+	if err != nil {
+		return nil, err
+	}
 
-		return capResponse, err
-	})
+	return awaitResponse.Responses, nil
 }
 
 func (r *RuntimeBase) Rand() (*rand.Rand, error) {
@@ -132,6 +133,24 @@ func (r *RuntimeBase) Now() time.Time {
 type Runtime struct {
 	RuntimeBase
 	nextNodeCallId int32
+
+	// secrets memoizes the secrets retrieved during the execution, so each is only requested from the host once.
+	secrets map[secretKey]*sdk.Secret
+	// pendingSecrets holds the host requests that were sent but not awaited yet, by the secrets they fetch,
+	// so requesting a secret again before the first request is awaited does not send another one.
+	pendingSecrets map[secretKey]cre.Promise[map[secretKey]*sdk.SecretError]
+
+	// Redactor tracks the values of the retrieved secrets, so they can be scrubbed from logs and errors.
+	Redactor *SecretRedactor
+}
+
+type secretKey struct {
+	namespace string
+	id        string
+}
+
+func secretKeyOf(req *sdk.SecretRequest) secretKey {
+	return secretKey{namespace: req.Namespace, id: req.Id}
 }
 
 func normalizeSecretRequests(reqs []*sdk.SecretRequest) []*sdk.SecretRequest {
@@ -171,21 +190,66 @@ func (d *Runtime) GetSecrets(reqs []*sdk.SecretRequest) cre.Promise[[]*sdk.Secre
 	}
 
 	normalized := normalizeSecretRequests(reqs)
+	missing, pending := d.uncachedSecrets(normalized)
+	if len(missing) > 0 {
+		fetch, err := d.fetchSecrets(missing)
+		if err != nil {
+			return cre.PromiseFromResult[[]*sdk.Secret](nil, err)
+		}
+		pending = append(pending, fetch)
+	}
+
+	if len(pending) == 0 {
+		secrets, err := d.cachedSecrets(normalized, nil)
+		return cre.PromiseFromResult(secrets, err)
+	}
+
+	return cre.NewBasicPromise(func() ([]*sdk.Secret, error) {
+		var failures []*sdk.SecretError
+		seen := map[secretKey]bool{}
+		for _, fetch := range pending {
+			fetchFailures, err := fetch.Await()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, req := range normalized {
+				key := secretKeyOf(req)
+				if failure, ok := fetchFailures[key]; ok && !seen[key] {
+					seen[key] = true
+					failures = append(failures, failure)
+				}
+			}
+		}
+		return d.cachedSecrets(normalized, failures)
+	})
+}
 
+// fetchSecrets requests the secrets from the host, and records the request as pending for each of them.
+// The returned Promise stores the retrieved secrets and returns the failures by secret.
+func (d *Runtime) fetchSecrets(missing []*sdk.SecretRequest) (cre.Promise[map[secretKey]*sdk.SecretError], error) {
 	d.nextCallId++
 	myId := d.nextCallId
+	d.setLogCallID(myId)
 
 	sr := &sdk.GetSecretsRequest{
-		Requests:   normalized,
+		Requests:   missing,
 		CallbackId: myId,
 	}
 
-	if err := d.RuntimeHelpers.GetSecrets(sr, d.MaxResponseSize); err != nil {
-		return cre.PromiseFromResult[[]*sdk.Secret](nil, err)
+	err := d.RuntimeHelpers.GetSecrets(sr, d.MaxResponseSize)
+	d.traceSecrets(sr, err)
+	if err != nil {
+		return nil, err
 	}
 
-	return cre.NewBasicPromise(func() ([]*sdk.Secret, error) {
+	fetch := cre.NewBasicPromise(func() (map[secretKey]*sdk.SecretError, error) {
+		for _, req := range missing {
+			delete(d.pendingSecrets, secretKeyOf(req))
+		}
+
 		awaitResponse, err := d.AwaitSecrets(&sdk.AwaitSecretsRequest{Ids: []int32{myId}}, d.MaxResponseSize)
+		d.traceSecretsAwait(myId, awaitResponse.GetResponses()[myId], err)
 		if err != nil {
 			return nil, err
 		}
@@ -194,28 +258,77 @@ func (d *Runtime) GetSecrets(reqs []*sdk.SecretRequest) cre.Promise[[]*sdk.Secre
 		if !ok {
 			return nil, fmt.Errorf("no secret response for callback %d", myId)
 		}
-		if len(resp.Responses) != len(normalized) {
-			return nil, fmt.Errorf("expected %d secrets, got %d", len(normalized), len(resp.Responses))
+		if len(resp.Responses) != len(missing) {
+			return nil, fmt.Errorf("expected %d secrets, got %d", len(missing), len(resp.Responses))
 		}
 
-		secrets := make([]*sdk.Secret, 0, len(resp.Responses))
-		var errs []string
-		for _, r := range resp.Responses {
+		failures := map[secretKey]*sdk.SecretError{}
+		for i, r := range resp.Responses {
 			if e := r.GetError(); e != nil {
-				errs = append(errs, fmt.Sprintf("%s: %s", e.Id, e.Error))
+				failures[secretKeyOf(missing[i])] = e
 			} else {
-				secrets = append(secrets, r.GetSecret())
+				if d.secrets == nil {
+					d.secrets = map[secretKey]*sdk.Secret{}
+				}
+				d.secrets[secretKeyOf(missing[i])] = r.GetSecret()
+				d.Redactor.Track(r.GetSecret())
 			}
 		}
-		if len(errs) > 0 {
-			return nil, fmt.Errorf("error(s) getting secrets: %s", strings.Join(errs, "; "))
-		}
-		return secrets, nil
+		return failures, nil
 	})
+
+	if d.pendingSecrets == nil {
+		d.pendingSecrets = map[secretKey]cre.Promise[map[secretKey]*sdk.SecretError]{}
+	}
+	for _, req := range missing {
+		d.pendingSecrets[secretKeyOf(req)] = fetch
+	}
+	return fetch, nil
+}
+
+// uncachedSecrets returns the requests that have not been retrieved or requested yet, without duplicates,
+// and the pending host requests that fetch the others.
+func (d *Runtime) uncachedSecrets(reqs []*sdk.SecretRequest) ([]*sdk.SecretRequest, []cre.Promise[map[secretKey]*sdk.SecretError]) {
+	var missing []*sdk.SecretRequest
+	var pending []cre.Promise[map[secretKey]*sdk.SecretError]
+	seen := map[secretKey]bool{}
+	for _, req := range reqs {
+		key := secretKeyOf(req)
+		if _, ok := d.secrets[key]; ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if fetch, ok := d.pendingSecrets[key]; ok {
+			if !slices.Contains(pending, fetch) {
+				pending = append(pending, fetch)
+			}
+			continue
+		}
+		missing = append(missing, req)
+	}
+	return missing, pending
+}
+
+// cachedSecrets returns the retrieved secrets in the order they were requested.
+// When any could not be retrieved, a *cre.SecretsError holds the ones that were.
+func (d *Runtime) cachedSecrets(reqs []*sdk.SecretRequest, failures []*sdk.SecretError) ([]*sdk.Secret, error) {
+	secrets := make([]*sdk.Secret, 0, len(reqs))
+	for _, req := range reqs {
+		if secret, ok := d.secrets[secretKeyOf(req)]; ok {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	if len(failures) > 0 {
+		return nil, &cre.SecretsError{Secrets: secrets, Failures: failures}
+	}
+	return secrets, nil
 }
 
 // RunInNodeMode switches the runtime into node mode, runs fn, then switches
-// back to DON mode. It modifies the shared modeErr and Mode fields without
+// back to DON mode. The logger's mode is restored to what it was before, such
+// as TEE when called from a TEE handler. It modifies the shared modeErr and Mode fields without
 // synchronization; this is safe because the runtime is single-threaded by
 // design (see the RuntimeBase godoc) and must not be used concurrently.
 func (d *Runtime) RunInNodeMode(fn func(nodeRuntime cre.NodeRuntime) *sdk.SimpleConsensusInputs) cre.Promise[values.Value] {
@@ -228,7 +341,10 @@ func (d *Runtime) RunInNodeMode(fn func(nodeRuntime cre.NodeRuntime) *sdk.Simple
 	nrt.Mode = sdk.Mode_MODE_NODE
 	d.modeErr = cre.DonModeCallInNodeMode()
 	d.SwitchModes(sdk.Mode_MODE_NODE)
+	logMode := d.logMode()
+	d.SetLogMode(LogModeNode)
 	observation := fn(nrt)
+	d.SetLogMode(logMode)
 	d.SwitchModes(sdk.Mode_MODE_DON)
 	nrt.modeErr = cre.NodeModeCallInDonMode()
 	d.modeErr = nil
@@ -155,6 +155,45 @@ func TestRuntime_CallCapability(t *testing.T) {
 	})
 }
 
+func TestRuntime_AwaitAll(t *testing.T) {
+	action1, err := basicactionmock.NewBasicActionCapability(t)
+	require.NoError(t, err)
+	action1.PerformAction = func(_ context.Context, input *basicaction.Inputs) (*basicaction.Outputs, error) {
+		return &basicaction.Outputs{AdaptedThing: fmt.Sprintf("%t", input.InputThing)}, nil
+	}
+
+	action2, err := actionandtriggermock.NewBasicCapability(t)
+	require.NoError(t, err)
+	action2.Action = func(_ context.Context, input *actionandtrigger.Input) (*actionandtrigger.Output, error) {
+		return &actionandtrigger.Output{Welcome: "hi " + input.Name}, nil
+	}
+
+	runtime := testutils.NewRuntime(t, nil)
+	var awaited [][]int32
+	helpers := runtime.RuntimeHelpers
+	runtime.RuntimeHelpers = &awaitOverride{
+		RuntimeHelpers: helpers,
+		await: func(request *sdk.AwaitCapabilitiesRequest, maxResponseSize uint64) (*sdk.AwaitCapabilitiesResponse, error) {
+			awaited = append(awaited, request.Ids)
+			return helpers.Await(request, maxResponseSize)
+		},
+	}
+
+	basic := &basicaction.BasicAction{}
+	other := &actionandtrigger.Basic{}
+	out1, out2, out3, errs := cre.AwaitAll3(
+		basic.PerformAction(runtime, &basicaction.Inputs{InputThing: true}),
+		basic.PerformAction(runtime, &basicaction.Inputs{InputThing: false}),
+		other.Action(runtime, &actionandtrigger.Input{Name: "there"}),
+	)
+
+	require.Nil(t, errs)
+	assert.Equal(t, "true", out1.AdaptedThing)
+	assert.Equal(t, "false", out2.AdaptedThing)
+	assert.Equal(t, "hi there", out3.Welcome)
+	assert.Equal(t, [][]int32{{1, 2, 3}}, awaited)
+}
+
 func TestRuntime_GetSecret(t *testing.T) {
 	t.Run("runs async", func(t *testing.T) {
 		test := func(_ string, rt cre.Runtime, _ *basictrigger.Outputs) (string, error) {
@@ -182,6 +221,74 @@ func TestRuntime_GetSecret(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("memoizes secrets for the execution", func(t *testing.T) {
+		secrets := testutils.Secrets{"main": {"secret1": "value1"}}
+		runtime := testutils.NewRuntime(t, secrets)
+
+		first, err := runtime.GetSecret(&sdk.SecretRequest{Id: "secret1"}).Await()
+		require.NoError(t, err)
+		secrets["main"]["secret1"] = "changed"
+
+		second, err := runtime.GetSecret(&sdk.SecretRequest{Namespace: "main", Id: "secret1"}).Await()
+		require.NoError(t, err)
+		assert.Equal(t, "value1", first.Value)
+		assert.Same(t, first, second)
+	})
+
+	t.Run("shares pending requests", func(t *testing.T) {
+		secrets := testutils.Secrets{"main": {"secret1": "value1", "secret2": "value2"}}
+		runtime := testutils.NewRuntime(t, secrets)
+
+		p1 := runtime.GetSecret(&sdk.SecretRequest{Id: "secret1"})
+		missing1 := runtime.GetSecret(&sdk.SecretRequest{Id: "missing"})
+		// The test host reads secrets when they are requested, so a second request for secret1 would see the new value.
+		secrets["main"]["secret1"] = "changed"
+		secrets["main"]["missing"] = "found"
+		p2 := runtime.GetSecrets([]*sdk.SecretRequest{{Namespace: "main", Id: "secret1"}, {Id: "secret2"}})
+		missing2 := runtime.GetSecret(&sdk.SecretRequest{Id: "missing"})
+
+		both, err := p2.Await()
+		require.NoError(t, err)
+		require.Len(t, both, 2)
+		assert.Equal(t, "value1", both[0].Value)
+		assert.Equal(t, "value2", both[1].Value)
+
+		first, err := p1.Await()
+		require.NoError(t, err)
+		assert.Same(t, first, both[0])
+
+		_, err = missing2.Await()
+		assert.ErrorContains(t, err, "missing: could not find secret main")
+		_, err = missing1.Await()
+		assert.ErrorContains(t, err, "missing: could not find secret main")
+	})
+
+	t.Run("does not memoize failures", func(t *testing.T) {
+		secrets := testutils.Secrets{"main": {}}
+		runtime := testutils.NewRuntime(t, secrets)
+
+		_, err := runtime.GetSecret(&sdk.SecretRequest{Id: "secret1"}).Await()
+		require.Error(t, err)
+		secrets["main"]["secret1"] = "value1"
+
+		secret, err := runtime.GetSecret(&sdk.SecretRequest{Id: "secret1"}).Await()
+		require.NoError(t, err)
+		assert.Equal(t, "value1", secret.Value)
+	})
+
+	t.Run("reports partial results", func(t *testing.T) {
+		runtime := testutils.NewRuntime(t, testutils.Secrets{"main": {"secret1": "value1"}})
+
+		_, err := runtime.GetSecrets([]*sdk.SecretRequest{{Id: "secret1"}, {Id: "missing"}, {Id: "secret1"}}).Await()
+
+		var secretsErr *cre.SecretsError
+		require.ErrorAs(t, err, &secretsErr)
+		require.Len(t, secretsErr.Secrets, 2)
+		assert.Equal(t, "value1", secretsErr.Secrets[0].Value)
+		require.Len(t, secretsErr.Failures, 1)
+		assert.Equal(t, "missing", secretsErr.Failures[0].Id)
+		assert.ErrorContains(t, err, "error(s) getting secrets: missing: could not find secret main")
+	})
 }
 
 func TestRuntime_Rand(t *testing.T) {
@@ -269,6 +376,43 @@ func TestDonRuntime_RunInNodeMode(t *testing.T) {
 		require.ErrorContains(t, err, anyError.Error())
 	})
 
+	t.Run("logs the mode", func(t *testing.T) {
+		mockSimpleConsensus(t, &consensusValues[int64]{GiveObservation: 1, WantResponse: 1})
+		runtime := testutils.NewRuntime(t, nil)
+		donLogger := runtime.Logger()
+
+		_, err := cre.RunInNodeMode(anyEnvConfig, runtime, func(_ string, nodeRuntime cre.NodeRuntime) (int64, error) {
+			nodeRuntime.Logger().Info("from node runtime")
+			donLogger.Info("from DON logger")
+			return 1, nil
+		}, cre.ConsensusMedianAggregation[int64]()).Await()
+		require.NoError(t, err)
+		donLogger.Info("after node mode")
+
+		logs := runtime.GetLogs()
+		require.Len(t, logs, 3)
+		assert.Contains(t, string(logs[0]), "msg=\"from node runtime\" mode=Node")
+		assert.Contains(t, string(logs[1]), "msg=\"from DON logger\" mode=Node")
+		assert.Contains(t, string(logs[2]), "msg=\"after node mode\" mode=DON")
+	})
+
+	t.Run("restores the TEE log mode", func(t *testing.T) {
+		mockSimpleConsensus(t, &consensusValues[int64]{GiveObservation: 1, WantResponse: 1})
+		runtime := testutils.NewTeeRuntime(t, nil)
+
+		_, err := cre.RunInNodeMode(anyEnvConfig, runtime.UsingTheDons(), func(_ string, nodeRuntime cre.NodeRuntime) (int64, error) {
+			nodeRuntime.Logger().Info("from node runtime")
+			return 1, nil
+		}, cre.ConsensusMedianAggregation[int64]()).Await()
+		require.NoError(t, err)
+		runtime.TeeRuntime.Logger().Info("after node mode")
+
+		logs := runtime.GetLogs()
+		require.Len(t, logs, 2)
+		assert.Contains(t, string(logs[0]), "msg=\"from node runtime\" mode=Node")
+		assert.Contains(t, string(logs[1]), "msg=\"after node mode\" mode=TEE")
+	})
+
 	t.Run("Node runtime in Don mode fails", func(t *testing.T) {
 		nodeCapability, err := nodeactionmock.NewBasicActionCapability(t)
 		require.NoError(t, err)
@@ -0,0 +1,222 @@
+package sdkimpl
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	caperrors "github.com/smartcontractkit/cre-sdk-go/capabilities/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// SecretsSpanCapabilityID is the capability ID of the spans recorded for secret fetches.
+const SecretsSpanCapabilityID = "secrets"
+
+// Span records a capability call or secret fetch made during an execution.
+type Span struct {
+	CallbackID   int32
+	CapabilityID string
+	Method       string
+	Mode         sdk.Mode
+	PayloadSize  int
+	ResponseSize int
+	// Start is the runtime's time when the call was made.
+	Start time.Time
+	// End is the runtime's time when the call was awaited. It is zero for calls that were never awaited.
+	End time.Time
+	// ErrorCode is the code of the call's capability error, Unknown for other errors, and 0 when the call succeeded.
+	ErrorCode caperrors.ErrorCode
+}
+
+// Duration is the time between the call and its await, or 0 if it was never awaited.
+func (s Span) Duration() time.Duration {
+	if s.End.IsZero() {
+		return 0
+	}
+	return s.End.Sub(s.Start)
+}
+
+// SpanSummary aggregates the spans of one capability method.
+type SpanSummary struct {
+	CapabilityID string
+	Method       string
+	Calls        int
+	Errors       int
+	Duration     time.Duration
+	PayloadSize  int
+	ResponseSize int
+}
+
+// Tracer records a Span for each capability call and secret fetch of an execution.
+// Tracing is opt-in: the runtime only records spans, and reads the time for them, when RuntimeBase.Tracer is set.
+type Tracer struct {
+	spans []*Span
+	open  map[int32]*Span
+}
+
+// NewTracer creates a Tracer without spans.
+func NewTracer() *Tracer {
+	return &Tracer{open: map[int32]*Span{}}
+}
+
+// Spans returns the recorded spans in the order the calls were made.
+func (t *Tracer) Spans() []Span {
+	spans := make([]Span, len(t.spans))
+	for i, span := range t.spans {
+		spans[i] = *span
+	}
+	return spans
+}
+
+// Summary aggregates the spans by capability ID and method, in the order each was first called.
+func (t *Tracer) Summary() []SpanSummary {
+	type key struct{ capabilityID, method string }
+	var summaries []SpanSummary
+	indexes := map[key]int{}
+	for _, span := range t.spans {
+		k := key{capabilityID: span.CapabilityID, method: span.Method}
+		i, ok := indexes[k]
+		if !ok {
+			i = len(summaries)
+			indexes[k] = i
+			summaries = append(summaries, SpanSummary{CapabilityID: span.CapabilityID, Method: span.Method})
+		}
+
+		summary := &summaries[i]
+		summary.Calls++
+		if span.ErrorCode != 0 {
+			summary.Errors++
+		}
+		summary.Duration += span.Duration()
+		summary.PayloadSize += span.PayloadSize
+		summary.ResponseSize += span.ResponseSize
+	}
+	return summaries
+}
+
+// LogSummary logs a line for each entry of the Summary.
+func (t *Tracer) LogSummary(logger *slog.Logger) {
+	if t == nil {
+		return
+	}
+
+	for _, summary := range t.Summary() {
+		logger.Info("capability calls",
+			"capabilityId", summary.CapabilityID,
+			"method", summary.Method,
+			"calls", summary.Calls,
+			"errors", summary.Errors,
+			"duration", summary.Duration,
+			"payloadSize", summary.PayloadSize,
+			"responseSize", summary.ResponseSize)
+	}
+}
+
+func (t *Tracer) start(span *Span) {
+	t.spans = append(t.spans, span)
+	t.open[span.CallbackID] = span
+}
+
+func (t *Tracer) end(callbackID int32, end time.Time, responseSize int, code caperrors.ErrorCode) {
+	span, ok := t.open[callbackID]
+	if !ok {
+		return
+	}
+
+	delete(t.open, callbackID)
+	span.End = end
+	span.ResponseSize = responseSize
+	span.ErrorCode = code
+}
+
+// errorCode returns the code of a capability error, or Unknown for other errors.
+func errorCode(err error) caperrors.ErrorCode {
+	var capErr caperrors.Error
+	if errors.As(err, &capErr) {
+		return capErr.Code()
+	}
+	return caperrors.Unknown
+}
+
+func (r *RuntimeBase) traceCall(request *sdk.CapabilityRequest, err error) {
+	if r.Tracer == nil {
+		return
+	}
+
+	now := r.Now()
+	r.Tracer.start(&Span{
+		CallbackID:   request.CallbackId,
+		CapabilityID: request.Id,
+		Method:       request.Method,
+		Mode:         r.Mode,
+		PayloadSize:  proto.Size(request.GetPayload()),
+		Start:        now,
+	})
+	if err != nil {
+		r.Tracer.end(request.CallbackId, now, 0, errorCode(err))
+	}
+}
+
+func (r *RuntimeBase) traceAwait(ids []int32, responses map[int32]*sdk.CapabilityResponse, err error) {
+	if r.Tracer == nil {
+		return
+	}
+
+	now := r.Now()
+	for _, id := range ids {
+		if err != nil {
+			r.Tracer.end(id, now, 0, errorCode(err))
+			continue
+		}
+
+		response, ok := responses[id]
+		if !ok {
+			r.Tracer.end(id, now, 0, caperrors.Unknown)
+		} else if msg := response.GetError(); msg != "" {
+			r.Tracer.end(id, now, len(msg), errorCode(caperrors.DeserializeErrorFromString(msg)))
+		} else {
+			r.Tracer.end(id, now, proto.Size(response.GetPayload()), 0)
+		}
+	}
+}
+
+func (r *RuntimeBase) traceSecrets(request *sdk.GetSecretsRequest, err error) {
+	if r.Tracer == nil {
+		return
+	}
+
+	now := r.Now()
+	r.Tracer.start(&Span{
+		CallbackID:   request.CallbackId,
+		CapabilityID: SecretsSpanCapabilityID,
+		Method:       "GetSecrets",
+		Mode:         r.Mode,
+		PayloadSize:  proto.Size(request),
+		Start:        now,
+	})
+	if err != nil {
+		r.Tracer.end(request.CallbackId, now, 0, errorCode(err))
+	}
+}
+
+func (r *RuntimeBase) traceSecretsAwait(callbackID int32, responses *sdk.SecretResponses, err error) {
+	if r.Tracer == nil {
+		return
+	}
+
+	now := r.Now()
+	if err != nil {
+		r.Tracer.end(callbackID, now, 0, errorCode(err))
+		return
+	}
+
+	var code caperrors.ErrorCode
+	for _, response := range responses.GetResponses() {
+		if e := response.GetError(); e != nil {
+			code = errorCode(caperrors.DeserializeErrorFromString(e.Error))
+			break
+		}
+	}
+	r.Tracer.end(callbackID, now, proto.Size(responses), code)
+}
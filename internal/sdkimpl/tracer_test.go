@@ -0,0 +1,115 @@
+package sdkimpl
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	caperrors "github.com/smartcontractkit/cre-sdk-go/capabilities/errors"
+)
+
+func TestTracer(t *testing.T) {
+	start := time.Unix(1767029356, 0)
+	helpers := &tracingHelpers{now: start, responses: map[int32]*sdk.CapabilityResponse{
+		1: {Response: &sdk.CapabilityResponse_Payload{Payload: &anypb.Any{Value: []byte("response")}}},
+		2: {Response: &sdk.CapabilityResponse_Error{Error: "Public:System:ResourceExhausted:slow down"}},
+	}}
+	runtime := &RuntimeBase{Mode: sdk.Mode_MODE_DON, RuntimeHelpers: helpers, Tracer: NewTracer()}
+
+	first := runtime.CallCapability(&sdk.CapabilityRequest{Id: "action@1.0.0", Method: "Perform", Payload: &anypb.Any{Value: []byte("payload")}})
+	second := runtime.CallCapability(&sdk.CapabilityRequest{Id: "action@1.0.0", Method: "Perform"})
+	_, err := first.Await()
+	require.NoError(t, err)
+	response, err := second.Await()
+	require.NoError(t, err)
+	require.NotEmpty(t, response.GetError())
+
+	helpers.callErr = errors.New("cannot find capability")
+	_, err = runtime.CallCapability(&sdk.CapabilityRequest{Id: "missing@1.0.0", Method: "Perform"}).Await()
+	require.Error(t, err)
+
+	spans := runtime.Tracer.Spans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, int32(1), spans[0].CallbackID)
+	assert.Equal(t, "action@1.0.0", spans[0].CapabilityID)
+	assert.Equal(t, "Perform", spans[0].Method)
+	assert.Equal(t, sdk.Mode_MODE_DON, spans[0].Mode)
+	assert.Equal(t, 9, spans[0].PayloadSize)
+	assert.Equal(t, 10, spans[0].ResponseSize)
+	assert.Equal(t, start, spans[0].Start)
+	assert.Equal(t, 2*time.Second, spans[0].Duration())
+	assert.Equal(t, caperrors.ErrorCode(0), spans[0].ErrorCode)
+	assert.Equal(t, caperrors.ResourceExhausted, spans[1].ErrorCode)
+	assert.Equal(t, caperrors.Unknown, spans[2].ErrorCode)
+	assert.Equal(t, time.Duration(0), spans[2].Duration())
+
+	assert.Equal(t, []SpanSummary{
+		{CapabilityID: "action@1.0.0", Method: "Perform", Calls: 2, Errors: 1, Duration: 4 * time.Second, PayloadSize: 9, ResponseSize: 10 + 41},
+		{CapabilityID: "missing@1.0.0", Method: "Perform", Calls: 1, Errors: 1},
+	}, runtime.Tracer.Summary())
+
+	buf := &bytes.Buffer{}
+	runtime.Tracer.LogSummary(slog.New(slog.NewTextHandler(buf, nil)))
+	assert.Contains(t, buf.String(), `msg="capability calls" capabilityId=action@1.0.0 method=Perform calls=2 errors=1 duration=4s payloadSize=9 responseSize=`)
+	assert.Contains(t, buf.String(), `capabilityId=missing@1.0.0 method=Perform calls=1 errors=1`)
+}
+
+func TestTracer_NotSet(t *testing.T) {
+	helpers := &tracingHelpers{now: time.Unix(1767029356, 0)}
+	runtime := &RuntimeBase{Mode: sdk.Mode_MODE_DON, RuntimeHelpers: helpers}
+
+	runtime.CallCapability(&sdk.CapabilityRequest{Id: "action@1.0.0", Method: "Perform"})
+
+	assert.Equal(t, 0, helpers.nowCalls)
+	var tracer *Tracer
+	assert.NotPanics(t, func() { tracer.LogSummary(slog.Default()) })
+}
+
+// tracingHelpers answers awaits from responses and advances the time by one second on each read.
+type tracingHelpers struct {
+	now       time.Time
+	nowCalls  int
+	callErr   error
+	responses map[int32]*sdk.CapabilityResponse
+}
+
+func (h *tracingHelpers) Call(*sdk.CapabilityRequest) error {
+	return h.callErr
+}
+
+func (h *tracingHelpers) Await(request *sdk.AwaitCapabilitiesRequest, _ uint64) (*sdk.AwaitCapabilitiesResponse, error) {
+	responses := map[int32]*sdk.CapabilityResponse{}
+	for _, id := range request.Ids {
+		responses[id] = h.responses[id]
+	}
+	return &sdk.AwaitCapabilitiesResponse{Responses: responses}, nil
+}
+
+func (h *tracingHelpers) GetSecrets(*sdk.GetSecretsRequest, uint64) error {
+	return errors.New("not supported")
+}
+
+func (h *tracingHelpers) AwaitSecrets(*sdk.AwaitSecretsRequest, uint64) (*sdk.AwaitSecretsResponse, error) {
+	return nil, errors.New("not supported")
+}
+
+func (h *tracingHelpers) SwitchModes(sdk.Mode) {}
+
+func (h *tracingHelpers) GetSource(sdk.Mode) rand.Source {
+	return rand.NewSource(1)
+}
+
+func (h *tracingHelpers) Now() time.Time {
+	h.nowCalls++
+	now := h.now
+	h.now = h.now.Add(time.Second)
+	return now
+}